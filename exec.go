@@ -0,0 +1,13 @@
+package rowan
+
+import "github.com/go-rowan/rowan/table"
+
+// SetWorkers sets the number of goroutines used by column-wise operations. This function is a convenience wrapper around table.SetWorkers.
+func SetWorkers(n int) {
+	table.SetWorkers(n)
+}
+
+// Workers returns the number of goroutines used by column-wise operations, see table.SetWorkers.
+func Workers() int {
+	return table.Workers()
+}
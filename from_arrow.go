@@ -0,0 +1,40 @@
+package rowan
+
+import (
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/go-rowan/rowan/internal/arrowio"
+	"github.com/go-rowan/rowan/table"
+)
+
+// FromArrow constructs a Table from one or more Arrow record batches sharing the same schema, whose rows are concatenated.
+// The values are copied, so the records can be released once FromArrow returns.
+//
+// Arrow types are mapped onto rowan column types like FromParquet does.
+func FromArrow(records ...arrow.RecordBatch) (*Table, error) {
+	return table.FromArrow(records...)
+}
+
+// FromArrowIPC reads an Arrow IPC file and constructs a Table from its contents.
+//
+// Both the file format, also known as Feather version 2, and the stream format are accepted, and are told apart by their content.
+// Compressed record batches, using LZ4 or Zstandard, are decompressed. Arrow types are mapped onto rowan column types like FromParquet does.
+func FromArrowIPC(path string) (*Table, error) {
+	data, columns, err := arrowio.ReadIPC(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
+
+// FromArrowIPCReader is like FromArrowIPC, for Arrow IPC data read from r. r is read sequentially, so it does not need to support random access.
+func FromArrowIPCReader(r io.Reader) (*Table, error) {
+	data, columns, err := arrowio.ReadIPCFrom(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
@@ -0,0 +1,146 @@
+package rowan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/go-rowan/rowan/table"
+)
+
+func TestArrowRoundTrip(t *testing.T) {
+	full := typedTable(t)
+
+	rec, err := full.ToArrow()
+	if err != nil {
+		t.Fatalf("ToArrow: %v", err)
+	}
+	defer rec.Release()
+
+	wantTypes := []arrow.Type{arrow.INT64, arrow.FLOAT64, arrow.STRING, arrow.BOOL, arrow.TIMESTAMP}
+	for i, f := range rec.Schema().Fields() {
+		if f.Name != full.Columns()[i] || f.Type.ID() != wantTypes[i] || !f.Nullable {
+			t.Errorf("field %d = %v, want nullable %s of type %s", i, f, full.Columns()[i], wantTypes[i])
+		}
+	}
+
+	got, err := FromArrow(rec)
+	if err != nil {
+		t.Fatalf("FromArrow: %v", err)
+	}
+	assertSameTable(t, got, full)
+
+	first, err := full.First(2).ToArrow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Release()
+	last, err := full.Last(3).ToArrow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer last.Release()
+
+	got, err = FromArrow(first, last)
+	if err != nil {
+		t.Fatalf("FromArrow of two records: %v", err)
+	}
+	assertSameTable(t, got, full)
+}
+
+func TestArrowMixedColumns(t *testing.T) {
+	tbl, err := New(map[string][]any{
+		"n": {int64(1), 2.5, nil},
+		"s": {int64(1), "a", true},
+		"b": {[]byte("x"), nil, []byte{}},
+	}, []string{"n", "s", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := tbl.ToArrow()
+	if err != nil {
+		t.Fatalf("ToArrow: %v", err)
+	}
+	defer rec.Release()
+
+	got, err := FromArrow(rec)
+	if err != nil {
+		t.Fatalf("FromArrow: %v", err)
+	}
+
+	want, err := New(map[string][]any{
+		"n": {1.0, 2.5, nil},
+		"s": {"1", "a", "true"},
+		"b": {[]byte("x"), nil, []byte{}},
+	}, []string{"n", "s", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameTable(t, got, want)
+}
+
+func TestArrowIPCRoundTrip(t *testing.T) {
+	full := typedTable(t)
+
+	for _, stream := range []bool{false, true} {
+		for _, c := range []table.ArrowIPCCompression{table.ArrowIPCUncompressed, table.ArrowIPCLz4, table.ArrowIPCZstd} {
+			t.Run(fmt.Sprint("stream=", stream, "/", c), func(t *testing.T) {
+				opts := []table.WriteArrowIPCOption{table.WithArrowIPCCompression(c)}
+				if stream {
+					opts = append(opts, table.WithArrowIPCStream())
+				}
+
+				path := filepath.Join(t.TempDir(), "t.arrow")
+				if err := full.WriteArrowIPC(path, opts...); err != nil {
+					t.Fatalf("WriteArrowIPC: %v", err)
+				}
+
+				got, err := FromArrowIPC(path)
+				if err != nil {
+					t.Fatalf("FromArrowIPC: %v", err)
+				}
+				assertSameTable(t, got, full)
+
+				f, err := os.Open(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer f.Close()
+
+				got, err = FromArrowIPCReader(f)
+				if err != nil {
+					t.Fatalf("FromArrowIPCReader: %v", err)
+				}
+				assertSameTable(t, got, full)
+			})
+		}
+	}
+}
+
+func TestArrowIPCErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"compression", func() error {
+			return typedTable(t).WriteArrowIPC(filepath.Join(dir, "x.arrow"), table.WithArrowIPCCompression(99))
+		}, "unknown arrow ipc compression 99"},
+		{"missing file", func() error { _, err := FromArrowIPC(filepath.Join(dir, "missing.arrow")); return err }, "missing.arrow"},
+		{"not arrow", func() error { _, err := FromArrowIPCReader(strings.NewReader("a,b\n1,2\n")); return err }, "arrow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
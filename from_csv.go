@@ -1,7 +1,10 @@
 package rowan
 
 import (
+	"io"
+
 	"github.com/go-rowan/rowan/internal/csv"
+	"github.com/go-rowan/rowan/internal/parser"
 	"github.com/go-rowan/rowan/table"
 )
 
@@ -10,6 +13,18 @@ import (
 // This alias allows CSV-related options to be exposed through the rowan package without requiring users to import the internal csv package directly.
 type CSVOption = csv.Option
 
+// RaggedPolicy is an alias of parser.RaggedPolicy, which controls how rows with an unexpected number of fields are handled.
+type RaggedPolicy = parser.RaggedPolicy
+
+const (
+	// RaggedError rejects ragged rows with an error. This is the default.
+	RaggedError = parser.RaggedError
+	// RaggedPad fills the missing trailing fields of short rows with nil. Rows with too many fields are still rejected.
+	RaggedPad = parser.RaggedPad
+	// RaggedDrop silently skips every ragged row.
+	RaggedDrop = parser.RaggedDrop
+)
+
 // FromCSV reads a CSV file and constructs a Table from its contents.
 //
 // The CSV file is parsed into column-oriented data, where each column is inferred to have a consistent type across all rows.
@@ -24,3 +39,18 @@ func FromCSV(path string, opts ...CSVOption) (*Table, error) {
 
 	return table.New(data, columns)
 }
+
+// FromCSVReader reads CSV data from r and constructs a Table from its contents.
+//
+// The reader does not need to support seeking, so standard input, HTTP response bodies, gzip streams and files from an embed.FS can be used directly.
+// Delimiter detection is done on a buffered peek of the first line, and records are parsed into columns as they are read rather than being loaded all at once.
+//
+// FromCSVReader accepts the same CSVOption values as FromCSV. The caller remains responsible for closing r.
+func FromCSVReader(r io.Reader, opts ...CSVOption) (*Table, error) {
+	data, columns, err := csv.ReadFrom(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
@@ -0,0 +1,118 @@
+package rowan
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFromCSVReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		columns []string
+		values  map[string][]any
+	}{
+		{
+			name:    "comma",
+			input:   "name,age\nAlice,30\nBob,25\n",
+			columns: []string{"name", "age"},
+			values:  map[string][]any{"name": {"Alice", "Bob"}, "age": {int64(30), int64(25)}},
+		},
+		{
+			name:    "detected semicolon",
+			input:   "name;score\nAlice;1.5\nBob;2\n",
+			columns: []string{"name", "score"},
+			values:  map[string][]any{"name": {"Alice", "Bob"}, "score": {1.5, 2.0}},
+		},
+		{
+			name:    "missing values",
+			input:   "a,b\n1,\n,x\n",
+			columns: []string{"a", "b"},
+			values:  map[string][]any{"a": {int64(1), nil}, "b": {nil, "x"}},
+		},
+		{
+			name:    "header only",
+			input:   "a,b\n",
+			columns: []string{"a", "b"},
+			values:  map[string][]any{"a": {}, "b": {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl, err := FromCSVReader(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("FromCSVReader: %v", err)
+			}
+			if !reflect.DeepEqual(tbl.Columns(), tt.columns) {
+				t.Fatalf("columns = %v, want %v", tbl.Columns(), tt.columns)
+			}
+			for c, want := range tt.values {
+				if got := tbl.MustCol(c).Values(); !reflect.DeepEqual(got, want) {
+					t.Errorf("column %s = %#v, want %#v", c, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFromCSVReaderMatchesFromCSV(t *testing.T) {
+	const input = "id,city,amount\n1,Jakarta,10.5\n2,Bandung,\n3,Surabaya,7\n"
+
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fromFile, err := FromCSV(path)
+	if err != nil {
+		t.Fatalf("FromCSV: %v", err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(input))
+	zw.Close()
+
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromReader, err := FromCSVReader(zr)
+	if err != nil {
+		t.Fatalf("FromCSVReader: %v", err)
+	}
+
+	if !reflect.DeepEqual(fromFile.Columns(), fromReader.Columns()) {
+		t.Fatalf("columns = %v, want %v", fromReader.Columns(), fromFile.Columns())
+	}
+	for _, c := range fromFile.Columns() {
+		if got, want := fromReader.MustCol(c).Values(), fromFile.MustCol(c).Values(); !reflect.DeepEqual(got, want) {
+			t.Errorf("column %s = %#v, want %#v", c, got, want)
+		}
+	}
+}
+
+func TestFromCSVReaderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input io.Reader
+	}{
+		{name: "empty", input: strings.NewReader("")},
+		{name: "ragged row", input: strings.NewReader("a,b\n1,2,3\n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromCSVReader(tt.input); err == nil {
+				t.Fatal("FromCSVReader: expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package rowan
+
+import (
+	"io"
+
+	"github.com/go-rowan/rowan/internal/jsonio"
+	"github.com/go-rowan/rowan/table"
+)
+
+// JSONOption is an alias of jsonio.Option used to configure JSON and JSON Lines reading behavior.
+type JSONOption = jsonio.Option
+
+// WithFlatten returns a JSONOption that reads nested objects as separate columns, named by joining the keys with dots.
+//
+// For example, the record {"user": {"id": 1, "name": "Ann"}} produces the columns user.id and user.name. Without it, nested objects are read as map[string]any values.
+// Arrays are always read as []any values.
+func WithFlatten() JSONOption {
+	return jsonio.WithFlatten()
+}
+
+// FromJSON reads a JSON file and constructs a Table from its contents, see FromJSONReader.
+func FromJSON(path string, opts ...JSONOption) (*Table, error) {
+	data, columns, err := jsonio.Read(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
+
+// FromJSONReader reads a JSON document from r and constructs a Table from its contents.
+//
+// Two layouts are accepted:
+//   - an array of records, such as [{"a": 1, "b": "x"}, {"a": 2}], with one row per record. The columns are the union of the keys of all records, in order of first appearance,
+//     and keys missing from a record are read as missing values.
+//   - an object of columns, such as {"a": [1, 2], "b": ["x", null]}, with the columns in the order of the object. All arrays must have the same length.
+//
+// JSON numbers are read as int64 when they are integers and as float64 otherwise, and columns mixing both are promoted to float64.
+// Columns of strings that all hold RFC 3339 times, as written by Table.WriteJSON, are read as time.Time. null is read as a missing value.
+//
+// The caller remains responsible for closing r.
+func FromJSONReader(r io.Reader, opts ...JSONOption) (*Table, error) {
+	data, columns, err := jsonio.ReadFrom(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
+
+// FromJSONL reads a JSON Lines file and constructs a Table from its contents, see FromJSONLReader.
+func FromJSONL(path string, opts ...JSONOption) (*Table, error) {
+	data, columns, err := jsonio.ReadLines(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
+
+// FromJSONLReader reads JSON Lines data, one record per line, from r and constructs a Table from its contents.
+//
+// Records are decoded one at a time as they are read, so the input is never loaded all at once. Blank lines are skipped.
+// The columns are the union of the keys of all records, in order of first appearance, and keys missing from a record are read as missing values.
+// Values are converted as described in FromJSONReader.
+//
+// The caller remains responsible for closing r.
+func FromJSONLReader(r io.Reader, opts ...JSONOption) (*Table, error) {
+	data, columns, err := jsonio.ReadLinesFrom(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
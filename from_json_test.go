@@ -0,0 +1,168 @@
+package rowan
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-rowan/rowan/table"
+)
+
+func jsonTable(tb testing.TB) *Table {
+	tb.Helper()
+
+	tbl, err := New(map[string][]any{
+		"id":    {int64(1), int64(2), int64(3)},
+		"score": {1.0, nil, 2.5},
+		"name":  {"Ann", "Bob \"B\"", nil},
+		"ok":    {true, false, true},
+		"at":    {time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), nil, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}, []string{"id", "score", "name", "ok", "at"})
+	if err != nil {
+		tb.Fatalf("New: %v", err)
+	}
+	return tbl
+}
+
+func assertSameTable(tb testing.TB, got, want *Table) {
+	tb.Helper()
+
+	if !reflect.DeepEqual(got.Columns(), want.Columns()) || got.Len() != want.Len() {
+		tb.Fatalf("got %v with %d rows, want %v with %d rows", got.Columns(), got.Len(), want.Columns(), want.Len())
+	}
+	for _, c := range want.Columns() {
+		if g, w := got.MustCol(c).Values(), want.MustCol(c).Values(); !reflect.DeepEqual(g, w) {
+			tb.Errorf("column %s = %#v, want %#v", c, g, w)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	full := jsonTable(t)
+
+	tests := []struct {
+		name  string
+		file  string
+		write func(tbl *Table, path string) error
+		read  func(path string) (*Table, error)
+	}{
+		{
+			name:  "records",
+			file:  "t.json",
+			write: func(tbl *Table, path string) error { return tbl.WriteJSON(path) },
+			read:  func(path string) (*Table, error) { return FromJSON(path) },
+		},
+		{
+			name:  "columns",
+			file:  "t.json",
+			write: func(tbl *Table, path string) error { return tbl.WriteJSON(path, table.WithJSONColumns()) },
+			read:  func(path string) (*Table, error) { return FromJSON(path) },
+		},
+		{
+			name:  "lines",
+			file:  "t.jsonl",
+			write: func(tbl *Table, path string) error { return tbl.WriteJSONL(path) },
+			read:  func(path string) (*Table, error) { return FromJSONL(path) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := tt.write(full, path); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			got, err := tt.read(path)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			assertSameTable(t, got, full)
+		})
+	}
+}
+
+func TestJSONRoundTripWithoutRows(t *testing.T) {
+	empty, err := jsonTable(t).Filter("id > 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "columns.json")
+	if err := empty.WriteJSON(path, table.WithJSONColumns()); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FromJSON(path)
+	if err != nil {
+		t.Fatalf("FromJSON of the columns layout: %v", err)
+	}
+	if !reflect.DeepEqual(got.Columns(), empty.Columns()) || got.Len() != 0 {
+		t.Errorf("got %v with %d rows, want %v without rows", got.Columns(), got.Len(), empty.Columns())
+	}
+
+	tests := []struct {
+		name  string
+		write func(path string) error
+		read  func(path string) (*Table, error)
+	}{
+		{"records", func(path string) error { return empty.WriteJSON(path) }, func(path string) (*Table, error) { return FromJSON(path) }},
+		{"lines", empty.WriteJSONL, func(path string) (*Table, error) { return FromJSONL(path) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "empty")
+			if err := tt.write(path); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if _, err := tt.read(path); err == nil || !strings.Contains(err.Error(), "data is empty") {
+				t.Fatalf("err = %v, want data is empty", err)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	b, err := jsonTable(t).First(2).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[{"id":1,"score":1.0,"name":"Ann","ok":true,"at":"2024-01-02T03:04:05Z"},` +
+		`{"id":2,"score":null,"name":"Bob \"B\"","ok":false,"at":null}]`
+	if string(b) != want {
+		t.Errorf("MarshalJSON =\n%s\nwant\n%s", b, want)
+	}
+
+	got, err := FromJSONReader(strings.NewReader(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameTable(t, got, jsonTable(t).First(2))
+}
+
+func TestJSONNestedValuesFillMode(t *testing.T) {
+	input := `{"id": 1, "tags": ["a"], "user": {"name": "Ann"}}
+{"id": 2, "tags": ["b", "c"], "user": {"name": "Bob"}}
+{"id": 3, "tags": ["b", "c"], "user": null}
+{"id": 4, "tags": null, "user": {"name": "Bob"}}
+`
+	tbl, err := FromJSONLReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filled, err := tbl.FillNAStrategy(table.FillMode)
+	if err != nil {
+		t.Fatalf("FillNAStrategy: %v", err)
+	}
+
+	if got := filled.MustCol("tags").At(3); !reflect.DeepEqual(got, []any{"b", "c"}) {
+		t.Errorf("tags[3] = %#v, want [b c]", got)
+	}
+	if got := filled.MustCol("user").At(2); !reflect.DeepEqual(got, map[string]any{"name": "Bob"}) {
+		t.Errorf("user[2] = %#v, want the object of Bob", got)
+	}
+}
@@ -0,0 +1,48 @@
+package rowan
+
+import (
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/go-rowan/rowan/internal/arrowio"
+	"github.com/go-rowan/rowan/table"
+)
+
+// ParquetOption is an alias of arrowio.ParquetOption used to configure Parquet reading behavior.
+type ParquetOption = arrowio.ParquetOption
+
+// WithParquetColumns returns a ParquetOption that reads only the named columns. The other columns are not decoded.
+//
+// The columns keep the order of the file. An error is returned if no names are given or a named column does not exist.
+func WithParquetColumns(names ...string) ParquetOption {
+	return arrowio.WithColumns(names...)
+}
+
+// FromParquet reads a Parquet file and constructs a Table from its contents.
+//
+// Parquet types are mapped onto rowan column types:
+//   - INT32 and INT64 columns, including the integer logical types, are read as int64. Unsigned 64-bit values that do not fit in an int64 are reported as errors.
+//   - FLOAT and DOUBLE columns, and DECIMAL columns, are read as float64
+//   - BOOLEAN columns are read as bool
+//   - BYTE_ARRAY columns annotated as UTF8 strings are read as string, other BYTE_ARRAY columns as []byte
+//   - TIMESTAMP columns, of any precision, are read as time.Time in their time zone or UTC, and DATE columns as time.Time at midnight UTC
+//
+// Null values, in optional columns, are read as missing values. Dictionary-encoded columns are decoded. Nested types such as lists and structs are not supported.
+// The file is decoded one batch of rows at a time, and only the columns selected with WithParquetColumns are decoded.
+func FromParquet(path string, opts ...ParquetOption) (*Table, error) {
+	data, columns, err := arrowio.ReadParquet(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
+
+// FromParquetReader is like FromParquet, for Parquet data read from r, such as an *os.File or a *bytes.Reader.
+// The Parquet footer is at the end of the data, so r must support random access.
+func FromParquetReader(r parquet.ReaderAtSeeker, opts ...ParquetOption) (*Table, error) {
+	data, columns, err := arrowio.ReadParquetFrom(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
@@ -0,0 +1,157 @@
+package rowan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-rowan/rowan/table"
+)
+
+func typedTable(tb testing.TB) *Table {
+	tb.Helper()
+
+	tbl, err := New(map[string][]any{
+		"id":    {int64(1), int64(2), int64(3), int64(4), int64(5)},
+		"score": {1.5, nil, 2.0, -0.25, 1e10},
+		"name":  {"Ann", "Bob", nil, "", "Eve"},
+		"ok":    {true, false, nil, true, false},
+		"at": {
+			time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), nil, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), time.Date(2030, 2, 3, 4, 5, 6, 0, time.UTC),
+		},
+	}, []string{"id", "score", "name", "ok", "at"})
+	if err != nil {
+		tb.Fatalf("New: %v", err)
+	}
+	return tbl
+}
+
+func TestParquetRoundTrip(t *testing.T) {
+	full := typedTable(t)
+
+	tests := []struct {
+		compression  table.ParquetCompression
+		rowGroupSize int
+	}{
+		{table.ParquetSnappy, 0},
+		{table.ParquetUncompressed, 2},
+		{table.ParquetGzip, 0},
+		{table.ParquetZstd, 1},
+		{table.ParquetBrotli, 0},
+		{table.ParquetLz4, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.compression, "/", tt.rowGroupSize), func(t *testing.T) {
+			opts := []table.WriteParquetOption{table.WithParquetCompression(tt.compression)}
+			if tt.rowGroupSize > 0 {
+				opts = append(opts, table.WithParquetRowGroupSize(tt.rowGroupSize))
+			}
+
+			path := filepath.Join(t.TempDir(), "t.parquet")
+			if err := full.WriteParquet(path, opts...); err != nil {
+				t.Fatalf("WriteParquet: %v", err)
+			}
+			got, err := FromParquet(path)
+			if err != nil {
+				t.Fatalf("FromParquet: %v", err)
+			}
+			assertSameTable(t, got, full)
+		})
+	}
+}
+
+func TestParquetColumns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "t.parquet")
+	if err := typedTable(t).WriteParquet(path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FromParquet(path, WithParquetColumns("at", "id"))
+	if err != nil {
+		t.Fatalf("FromParquet: %v", err)
+	}
+	want, err := typedTable(t).Select("id", "at")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameTable(t, got, want)
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	got, err = FromParquetReader(f, WithParquetColumns("name"))
+	if err != nil {
+		t.Fatalf("FromParquetReader: %v", err)
+	}
+	if want, err = typedTable(t).Select("name"); err != nil {
+		t.Fatal(err)
+	}
+	assertSameTable(t, got, want)
+}
+
+func TestParquetMixedColumns(t *testing.T) {
+	tbl, err := New(map[string][]any{
+		"n": {int64(1), 2.5, nil},
+		"s": {int64(1), "a", true},
+	}, []string{"n", "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "t.parquet")
+	if err := tbl.WriteParquet(path); err != nil {
+		t.Fatalf("WriteParquet: %v", err)
+	}
+	got, err := FromParquet(path)
+	if err != nil {
+		t.Fatalf("FromParquet: %v", err)
+	}
+
+	want, err := New(map[string][]any{
+		"n": {1.0, 2.5, nil},
+		"s": {"1", "a", "true"},
+	}, []string{"n", "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameTable(t, got, want)
+}
+
+func TestParquetErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "t.parquet")
+	if err := typedTable(t).WriteParquet(path); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		read func() error
+		want string
+	}{
+		{"no column names", func() error { _, err := FromParquet(path, WithParquetColumns()); return err }, "parquet: no columns specified"},
+		{"missing column", func() error { _, err := FromParquet(path, WithParquetColumns("id", "nope")); return err }, "parquet: column nope not found"},
+		{"missing file", func() error { _, err := FromParquet(path + ".missing"); return err }, "parquet:"},
+		{"row group size", func() error {
+			return typedTable(t).WriteParquet(filepath.Join(t.TempDir(), "x.parquet"), table.WithParquetRowGroupSize(0))
+		}, "row group size must be positive"},
+		{"compression", func() error {
+			return typedTable(t).WriteParquet(filepath.Join(t.TempDir(), "x.parquet"), table.WithParquetCompression(99))
+		}, "unknown parquet compression 99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.read(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package rowan
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-rowan/rowan/internal/sqlio"
+	"github.com/go-rowan/rowan/table"
+)
+
+// FromRows reads all the rows of a database/sql result set and constructs a Table from them, with one column per result column. rows is closed.
+//
+// Values are mapped onto rowan column types according to the database type of their column:
+//   - integer columns of any width are read as int64
+//   - floating point, decimal and numeric columns are read as float64
+//   - boolean columns are read as bool, including booleans returned by the driver as integers or text
+//   - text columns are read as string, binary columns as []byte
+//   - date and time columns are read as time.Time, including times returned by the driver as text
+//
+// NULL values are read as missing values. Values that can not be converted are kept as returned by the driver.
+// Result columns must have distinct names, use aliases in the query otherwise.
+func FromRows(rows *sql.Rows) (*Table, error) {
+	data, columns, err := sqlio.ReadRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
+
+// FromQuery runs a query on db with the given arguments, and constructs a Table from its results like FromRows does.
+func FromQuery(ctx context.Context, db *sql.DB, query string, args ...any) (*Table, error) {
+	data, columns, err := sqlio.Query(ctx, db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(data, columns)
+}
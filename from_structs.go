@@ -13,6 +13,10 @@ import (
 // The column name is derived from the struct field name by default, or from the `rowan` struct tag if present.
 //
 // Fields tagged with `rowan:"-"` or unexported fields are ignored.
+// Pointer fields are dereferenced, and nil pointers are stored as missing (nil) values.
+//
+// An optional Schema converts the values of the listed columns to their declared types, for example to store an int field as TypeFloat.
+// Only the first Schema is used if multiple are provided.
 //
 // Example:
 //
@@ -23,7 +27,7 @@ import (
 //	}
 //
 //	tbl, err := rowan.FromStructs([]User{...})
-func FromStructs[T any](rows []T) (*Table, error) {
+func FromStructs[T any](rows []T, schema ...Schema) (*Table, error) {
 	if len(rows) == 0 {
 		return nil, fmt.Errorf("rowan: empty slice")
 	}
@@ -47,8 +51,16 @@ func FromStructs[T any](rows []T) (*Table, error) {
 		data[columnName] = []any{}
 	}
 
+	var fields Schema
+	if len(schema) > 0 {
+		fields = schema[0]
+		if err := fields.Check(columns); err != nil {
+			return nil, err
+		}
+	}
+
 	// fill rows
-	for _, row := range rows {
+	for r, row := range rows {
 		v := reflect.ValueOf(row)
 
 		for i := 0; i < t.NumField(); i++ {
@@ -57,13 +69,34 @@ func FromStructs[T any](rows []T) (*Table, error) {
 				continue
 			}
 
-			data[columnName] = append(data[columnName], v.Field(i).Interface())
+			value := fieldValue(v.Field(i))
+			if f, ok := fields.Field(columnName); ok {
+				converted, err := f.ConvertValue(r+1, value)
+				if err != nil {
+					return nil, err
+				}
+				value = converted
+			}
+
+			data[columnName] = append(data[columnName], value)
 		}
 	}
 
 	return table.New(data, columns)
 }
 
+// fieldValue returns the value of a struct field, dereferencing pointers so that nil pointers become missing values.
+func fieldValue(v reflect.Value) any {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		return v.Elem().Interface()
+	}
+
+	return v.Interface()
+}
+
 func processField(f reflect.StructField) (string, bool) {
 	if !f.IsExported() {
 		return "", false
@@ -0,0 +1,116 @@
+package arrowio
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+)
+
+// FromRecords converts record batches sharing a schema into columns, in the order of the schema.
+func FromRecords(records []arrow.RecordBatch) (map[string][]any, []string, error) {
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("arrow: no record batches")
+	}
+
+	schema := records[0].Schema()
+	c := newCollector(schema)
+	for i, rec := range records {
+		if !rec.Schema().Equal(schema) {
+			return nil, nil, fmt.Errorf("arrow: record batch %d has a different schema", i)
+		}
+		if err := c.add(rec); err != nil {
+			return nil, nil, fmt.Errorf("arrow: %w", err)
+		}
+	}
+
+	data, columns, err := c.result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("arrow: %w", err)
+	}
+	return data, columns, nil
+}
+
+// ReadIPC reads an Arrow IPC file, in the file format, also known as Feather version 2, or in the stream format.
+func ReadIPC(path string) (map[string][]any, []string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	magic := make([]byte, len(ipc.Magic))
+	if _, err := io.ReadFull(f, magic); err != nil || !bytes.Equal(magic, ipc.Magic) {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, nil, err
+		}
+		return readStream(bufio.NewReader(f))
+	}
+
+	fr, err := ipc.NewFileReader(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("arrow: %w", err)
+	}
+	defer fr.Close()
+
+	c := newCollector(fr.Schema())
+	for i := 0; i < fr.NumRecords(); i++ {
+		rec, err := fr.RecordBatch(i)
+		if err != nil {
+			return nil, nil, fmt.Errorf("arrow: %w", err)
+		}
+		if err := c.add(rec); err != nil {
+			return nil, nil, fmt.Errorf("arrow: %w", err)
+		}
+	}
+
+	data, columns, err := c.result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("arrow: %w", err)
+	}
+	return data, columns, nil
+}
+
+// ReadIPCFrom reads Arrow IPC data from r, in the stream format or in the file format.
+//
+// The file format starts with the stream of its record batches, which is read as such, so r does not need to support random access.
+func ReadIPCFrom(r io.Reader) (map[string][]any, []string, error) {
+	br := bufio.NewReader(r)
+
+	// the magic string is padded to 8 bytes
+	if prefix, err := br.Peek(8); err == nil && bytes.Equal(prefix[:len(ipc.Magic)], ipc.Magic) {
+		if _, err := br.Discard(8); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return readStream(br)
+}
+
+func readStream(r io.Reader) (map[string][]any, []string, error) {
+	sr, err := ipc.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("arrow: %w", err)
+	}
+	defer sr.Release()
+
+	c := newCollector(sr.Schema())
+	for sr.Next() {
+		if err := c.add(sr.RecordBatch()); err != nil {
+			return nil, nil, fmt.Errorf("arrow: %w", err)
+		}
+	}
+	if err := sr.Err(); err != nil {
+		return nil, nil, fmt.Errorf("arrow: %w", err)
+	}
+
+	data, columns, err := c.result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("arrow: %w", err)
+	}
+	return data, columns, nil
+}
@@ -0,0 +1,16 @@
+package arrowio
+
+type parquetOptions struct {
+	columns  []string
+	selected bool
+}
+
+type ParquetOption func(*parquetOptions)
+
+// WithColumns reads only the named columns, which keep the order of the file.
+func WithColumns(names ...string) ParquetOption {
+	return func(o *parquetOptions) {
+		o.columns = names
+		o.selected = true
+	}
+}
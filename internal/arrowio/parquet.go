@@ -0,0 +1,108 @@
+package arrowio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// parquetBatchSize is the number of rows decoded at a time.
+const parquetBatchSize = 64 * 1024
+
+// ReadParquet reads a Parquet file, see ReadParquetFrom.
+func ReadParquet(path string, argOpts ...ParquetOption) (map[string][]any, []string, error) {
+	rdr, err := file.OpenParquetFile(path, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parquet: %w", err)
+	}
+	defer rdr.Close()
+
+	return readParquet(rdr, argOpts)
+}
+
+// ReadParquetFrom reads Parquet data from r, decoding the requested columns one batch of rows at a time.
+func ReadParquetFrom(r parquet.ReaderAtSeeker, argOpts ...ParquetOption) (map[string][]any, []string, error) {
+	rdr, err := file.NewParquetReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parquet: %w", err)
+	}
+	defer rdr.Close()
+
+	return readParquet(rdr, argOpts)
+}
+
+func readParquet(rdr *file.Reader, argOpts []ParquetOption) (map[string][]any, []string, error) {
+	var opts parquetOptions
+	for _, opt := range argOpts {
+		opt(&opts)
+	}
+
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{BatchSize: parquetBatchSize}, memory.DefaultAllocator)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parquet: %w", err)
+	}
+
+	schema, err := fr.Schema()
+	if err != nil {
+		return nil, nil, fmt.Errorf("parquet: %w", err)
+	}
+
+	var indices []int
+	if opts.selected {
+		if len(opts.columns) == 0 {
+			return nil, nil, fmt.Errorf("parquet: no columns specified")
+		}
+		for _, c := range opts.columns {
+			if len(schema.FieldIndices(c)) == 0 {
+				return nil, nil, fmt.Errorf("parquet: column %s not found", c)
+			}
+		}
+
+		indices = []int{}
+		for i, f := range schema.Fields() {
+			for _, c := range opts.columns {
+				if f.Name == c {
+					indices = appendLeaves(indices, fr.Manifest.Fields[i])
+					break
+				}
+			}
+		}
+	}
+
+	rr, err := fr.GetRecordReader(context.Background(), indices, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parquet: %w", err)
+	}
+	defer rr.Release()
+
+	c := newCollector(rr.Schema())
+	for rr.Next() {
+		if err := c.add(rr.RecordBatch()); err != nil {
+			return nil, nil, fmt.Errorf("parquet: %w", err)
+		}
+	}
+	if err := rr.Err(); err != nil {
+		return nil, nil, fmt.Errorf("parquet: %w", err)
+	}
+
+	data, columns, err := c.result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("parquet: %w", err)
+	}
+	return data, columns, nil
+}
+
+// appendLeaves appends the indices of the Parquet leaf columns storing the field, which are several for nested fields.
+func appendLeaves(indices []int, f pqarrow.SchemaField) []int {
+	if f.IsLeaf() {
+		return append(indices, f.ColIndex)
+	}
+	for _, child := range f.Children {
+		indices = appendLeaves(indices, child)
+	}
+	return indices
+}
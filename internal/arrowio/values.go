@@ -0,0 +1,135 @@
+// Package arrowio reads Apache Arrow and Parquet data into column-oriented data.
+package arrowio
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// collector accumulates the columns of consecutive record batches sharing a schema.
+type collector struct {
+	columns []string
+	data    [][]any
+}
+
+func newCollector(schema *arrow.Schema) *collector {
+	fields := schema.Fields()
+	c := &collector{
+		columns: make([]string, len(fields)),
+		data:    make([][]any, len(fields)),
+	}
+	for j, f := range fields {
+		c.columns[j] = f.Name
+		c.data[j] = []any{}
+	}
+	return c
+}
+
+func (c *collector) add(rec arrow.RecordBatch) error {
+	for j := range c.columns {
+		values, err := Values(rec.Column(j))
+		if err != nil {
+			return fmt.Errorf("column %s: %w", c.columns[j], err)
+		}
+		c.data[j] = append(c.data[j], values...)
+	}
+	return nil
+}
+
+func (c *collector) result() (map[string][]any, []string, error) {
+	data := make(map[string][]any, len(c.columns))
+	for j, name := range c.columns {
+		if _, exists := data[name]; exists {
+			return nil, nil, fmt.Errorf("duplicate column %s", name)
+		}
+		data[name] = c.data[j]
+	}
+	return data, c.columns, nil
+}
+
+// Values converts the values of an Arrow array into the values of a rowan column. Null values are converted to nil.
+//
+// Signed and unsigned integers are converted to int64, floating point and decimal numbers to float64, strings to string, binary data to []byte,
+// and timestamps and dates to time.Time, in the time zone of the timestamp type or UTC. Dictionary-encoded arrays are decoded.
+// An error is returned for other types, and for unsigned integers that do not fit in an int64.
+func Values(arr arrow.Array) ([]any, error) {
+	n := arr.Len()
+	values := make([]any, n)
+
+	set := func(f func(i int) any) []any {
+		for i := 0; i < n; i++ {
+			if arr.IsValid(i) {
+				values[i] = f(i)
+			}
+		}
+		return values
+	}
+
+	switch a := arr.(type) {
+	case *array.Null:
+		return values, nil
+	case *array.Boolean:
+		return set(func(i int) any { return a.Value(i) }), nil
+	case *array.Int8:
+		return set(func(i int) any { return int64(a.Value(i)) }), nil
+	case *array.Int16:
+		return set(func(i int) any { return int64(a.Value(i)) }), nil
+	case *array.Int32:
+		return set(func(i int) any { return int64(a.Value(i)) }), nil
+	case *array.Int64:
+		return set(func(i int) any { return a.Value(i) }), nil
+	case *array.Uint8:
+		return set(func(i int) any { return int64(a.Value(i)) }), nil
+	case *array.Uint16:
+		return set(func(i int) any { return int64(a.Value(i)) }), nil
+	case *array.Uint32:
+		return set(func(i int) any { return int64(a.Value(i)) }), nil
+	case *array.Uint64:
+		for i := 0; i < n; i++ {
+			if a.IsValid(i) && a.Value(i) > math.MaxInt64 {
+				return nil, fmt.Errorf("value %d overflows int64", a.Value(i))
+			}
+		}
+		return set(func(i int) any { return int64(a.Value(i)) }), nil
+	case *array.Float16:
+		return set(func(i int) any { return float64(a.Value(i).Float32()) }), nil
+	case *array.Float32:
+		return set(func(i int) any { return float64(a.Value(i)) }), nil
+	case *array.Float64:
+		return set(func(i int) any { return a.Value(i) }), nil
+	case *array.Decimal128:
+		scale := a.DataType().(*arrow.Decimal128Type).Scale
+		return set(func(i int) any { return a.Value(i).ToFloat64(scale) }), nil
+	case *array.String:
+		return set(func(i int) any { return a.Value(i) }), nil
+	case *array.LargeString:
+		return set(func(i int) any { return a.Value(i) }), nil
+	case *array.StringView:
+		return set(func(i int) any { return a.Value(i) }), nil
+	case *array.Binary:
+		return set(func(i int) any { return append([]byte{}, a.Value(i)...) }), nil
+	case *array.LargeBinary:
+		return set(func(i int) any { return append([]byte{}, a.Value(i)...) }), nil
+	case *array.Timestamp:
+		toTime, err := a.DataType().(*arrow.TimestampType).GetToTimeFunc()
+		if err != nil {
+			return nil, err
+		}
+		return set(func(i int) any { return toTime(a.Value(i)) }), nil
+	case *array.Date32:
+		return set(func(i int) any { return a.Value(i).ToTime() }), nil
+	case *array.Date64:
+		return set(func(i int) any { return a.Value(i).ToTime() }), nil
+	case *array.Dictionary:
+		dict, err := Values(a.Dictionary())
+		if err != nil {
+			return nil, err
+		}
+		return set(func(i int) any { return dict[a.GetValueIndex(i)] }), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", arr.DataType())
+	}
+}
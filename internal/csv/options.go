@@ -1,7 +1,18 @@
 package csv
 
+import (
+	"github.com/go-rowan/rowan/internal/parser"
+	"github.com/go-rowan/rowan/table"
+)
+
 type options struct {
-	comma rune
+	comma       rune
+	comment     rune
+	lazyQuotes  bool
+	noHeader    bool
+	columnNames []string
+	skipRows    int
+	parse       parser.Options
 }
 
 func defaultOptions() options {
@@ -17,3 +28,75 @@ func WithDelimiter(r rune) Option {
 		o.comma = r
 	}
 }
+
+func WithNoHeader() Option {
+	return func(o *options) {
+		o.noHeader = true
+	}
+}
+
+func WithColumnNames(names ...string) Option {
+	return func(o *options) {
+		o.noHeader = true
+		o.columnNames = names
+	}
+}
+
+func WithSkipRows(n int) Option {
+	return func(o *options) {
+		o.skipRows = n
+	}
+}
+
+func WithComment(r rune) Option {
+	return func(o *options) {
+		o.comment = r
+	}
+}
+
+func WithLazyQuotes() Option {
+	return func(o *options) {
+		o.lazyQuotes = true
+	}
+}
+
+func WithNullValues(tokens ...string) Option {
+	return func(o *options) {
+		o.parse.NullValues = tokens
+	}
+}
+
+func WithRaggedRows(policy parser.RaggedPolicy) Option {
+	return func(o *options) {
+		o.parse.Ragged = policy
+	}
+}
+
+func WithSchema(schema parser.Schema) Option {
+	return func(o *options) {
+		o.parse.Schema = schema
+	}
+}
+
+func WithColumnTypes(types map[string]table.Type) Option {
+	return WithSchema(parser.SchemaFromTypes(types))
+}
+
+func WithColumns(names ...string) Option {
+	return func(o *options) {
+		o.parse.Columns = names
+	}
+}
+
+func WithTimeLayout(layouts ...string) Option {
+	return func(o *options) {
+		o.parse.TimeLayouts = append(o.parse.TimeLayouts, layouts...)
+	}
+}
+
+// WithFixedTypes fixes the type of every column outside the schema to the type of the first chunk holding values of the column, see parser.Options.FixTypes.
+func WithFixedTypes() Option {
+	return func(o *options) {
+		o.parse.FixTypes = true
+	}
+}
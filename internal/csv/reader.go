@@ -1,21 +1,63 @@
 package csv
 
 import (
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/go-rowan/rowan/internal/parser"
 )
 
 func Read(path string, argOpts ...Option) (map[string][]any, []string, error) {
-	source := NewCSVSource(path, argOpts...)
-
-	columns, rows, err := source.Read()
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, nil, err
 	}
+	defer f.Close()
+
+	return ReadFrom(f, argOpts...)
+}
+
+// ReadFrom parses CSV data from r, streaming each record straight into the column builders.
+func ReadFrom(r io.Reader, argOpts ...Option) (map[string][]any, []string, error) {
+	source := NewCSVSource(r, argOpts...)
+	return parser.ReadAll(source, source.opts.parse)
+}
+
+// Scan parses CSV data from r and calls fn with the column data of every chunkSize rows.
+//
+// Types are inferred from the cells of each chunk, unless WithFixedTypes is given.
+func Scan(r io.Reader, chunkSize int, fn func(map[string][]any, []string) error, argOpts ...Option) error {
+	source := NewCSVSource(r, argOpts...)
+	return parser.Chunks(source, chunkSize, fn, source.opts.parse)
+}
+
+// ScanFile parses the CSV file at path and calls fn with the column data of every chunkSize rows.
+//
+// The file is read twice: first to infer the type of every column from all its cells, see parser.InferSchema, then to parse the chunks with these types.
+func ScanFile(path string, chunkSize int, fn func(map[string][]any, []string) error, argOpts ...Option) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("scan: chunk size must be positive, got %d", chunkSize)
+	}
 
-	data, err := parser.ParseRows(columns, rows)
+	f, err := os.Open(path)
 	if err != nil {
-		return nil, nil, err
+		return err
+	}
+	defer f.Close()
+
+	source := NewCSVSource(f, argOpts...)
+	schema, err := parser.InferSchema(source, source.opts.parse)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
 	}
 
-	return data, columns, nil
+	source = NewCSVSource(f, argOpts...)
+	opts := source.opts.parse
+	opts.Schema = append(opts.Schema[:len(opts.Schema):len(opts.Schema)], schema...)
+	return parser.Chunks(source, chunkSize, fn, opts)
 }
@@ -2,75 +2,173 @@ package csv
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/csv"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 )
 
+// sniffSize is the number of bytes peeked from the input to detect the delimiter.
+// It is also the size of the buffered reader wrapping the input.
+const sniffSize = 64 * 1024
+
+// CSVSource reads CSV records from any io.Reader.
+//
+// The input is consumed as a stream: it does not need to support seeking, so standard input, HTTP bodies, decompressed streams and embedded files can all be used.
 type CSVSource struct {
-	path string
-	opts options
+	r       io.Reader
+	opts    options
+	reader  *csv.Reader
+	headers []string
+	pending []string
 }
 
-func NewCSVSource(path string, argOpts ...Option) *CSVSource {
+func NewCSVSource(r io.Reader, argOpts ...Option) *CSVSource {
 	opts := defaultOptions()
 	for _, opt := range argOpts {
 		opt(&opts)
 	}
 
 	return &CSVSource{
-		path: path,
+		r:    r,
 		opts: opts,
 	}
 }
 
-func (s *CSVSource) Read() ([]string, [][]string, error) {
-	f, err := os.Open(s.path)
-	if err != nil {
-		return nil, nil, err
+// Header returns the column names of the CSV input.
+//
+// The first call skips the configured leading rows, detects the delimiter if it was not configured, and consumes the header record.
+// For headerless input the column names are taken from the options or generated as col_0, col_1, ..., and the first record is kept as data.
+func (s *CSVSource) Header() ([]string, error) {
+	if s.reader != nil {
+		return s.headers, nil
 	}
-	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
-	if !scanner.Scan() {
-		return nil, nil, fmt.Errorf("csv: empty file")
+	br := bufio.NewReaderSize(s.r, sniffSize)
+
+	for i := 0; i < s.opts.skipRows; i++ {
+		if _, err := br.ReadString('\n'); err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("csv: empty file")
+			}
+			return nil, err
+		}
 	}
-	headerLine := scanner.Text()
 
 	delimiter := s.opts.comma
 	if delimiter == 0 {
-		commaCount := strings.Count(headerLine, ",")
-		semicolonCount := strings.Count(headerLine, ";")
-
-		delimiter = ','
-		if semicolonCount > commaCount {
-			delimiter = ';'
+		headerLine, err := peekLine(br, s.opts.comment)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	if _, err := f.Seek(0, 0); err != nil {
-		return nil, nil, err
+		delimiter = sniffDelimiter(headerLine)
 	}
 
-	r := csv.NewReader(f)
+	r := csv.NewReader(br)
 	r.Comma = delimiter
+	r.Comment = s.opts.comment
+	r.LazyQuotes = s.opts.lazyQuotes
+	r.FieldsPerRecord = -1
 	r.TrimLeadingSpace = true
+	r.ReuseRecord = true
 
-	records, err := r.ReadAll()
-	if err != nil {
-		return nil, nil, err
+	record, err := r.Read()
+	if err == io.EOF {
+		if !s.opts.noHeader || len(s.opts.columnNames) == 0 {
+			return nil, fmt.Errorf("csv: empty file")
+		}
+		record = nil
+	} else if err != nil {
+		return nil, err
 	}
-	if len(records) == 0 {
-		return nil, nil, fmt.Errorf("csv: empty file")
+
+	var headers []string
+
+	switch {
+	case len(s.opts.columnNames) > 0:
+		headers = make([]string, len(s.opts.columnNames))
+		copy(headers, s.opts.columnNames)
+	case s.opts.noHeader:
+		headers = make([]string, len(record))
+		for i := range record {
+			headers[i] = fmt.Sprintf("col_%d", i)
+		}
+	default:
+		headers = make([]string, len(record))
+		copy(headers, record)
 	}
 
-	headers := records[0]
 	if len(headers) == 0 {
-		return nil, nil, fmt.Errorf("csv: no columns found")
+		return nil, fmt.Errorf("csv: no columns found")
 	}
 
-	rows := records[1:]
+	if s.opts.noHeader && record != nil {
+		s.pending = make([]string, len(record))
+		copy(s.pending, record)
+	}
+
+	s.reader = r
+	s.headers = headers
+
+	return headers, nil
+}
+
+// Next returns the next data record.
+//
+// The returned slice is reused by subsequent calls and must not be retained. Next returns io.EOF once all records have been read.
+func (s *CSVSource) Next() ([]string, error) {
+	if s.reader == nil {
+		if _, err := s.Header(); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.pending != nil {
+		record := s.pending
+		s.pending = nil
+		return record, nil
+	}
+
+	return s.reader.Read()
+}
+
+// peekLine returns the first line of the buffered input that is not a comment, without consuming it.
+func peekLine(br *bufio.Reader, comment rune) (string, error) {
+	buf, err := br.Peek(sniffSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return "", err
+	}
+
+	if len(buf) == 0 {
+		return "", fmt.Errorf("csv: empty file")
+	}
+
+	for len(buf) > 0 {
+		line := buf
+		rest := []byte(nil)
+		if i := bytes.IndexByte(buf, '\n'); i != -1 {
+			line, rest = buf[:i], buf[i+1:]
+		}
+
+		if comment == 0 || !strings.HasPrefix(string(line), string(comment)) {
+			return string(line), nil
+		}
+
+		buf = rest
+	}
+
+	return "", nil
+}
+
+func sniffDelimiter(headerLine string) rune {
+	commaCount := strings.Count(headerLine, ",")
+	semicolonCount := strings.Count(headerLine, ";")
+
+	if semicolonCount > commaCount {
+		return ';'
+	}
 
-	return headers, rows, nil
+	return ','
 }
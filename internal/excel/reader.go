@@ -1,22 +1,51 @@
 package excel
 
-import "github.com/go-rowan/rowan/internal/parser"
+import (
+	"fmt"
+
+	"github.com/go-rowan/rowan/internal/parser"
+)
 
 func Read(path string, argOpts ...Option) (map[string][]any, []string, error) {
 	source, err := NewExcelSource(path, argOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
+	defer source.Close()
+
+	return parser.ReadAll(source, source.opts.parse)
+}
 
-	columns, rows, err := source.Read()
+// Scan streams the rows of the sheet and calls fn with the column data of every chunkSize rows.
+//
+// The sheet is read twice: first to infer the type of every column from all its cells, see parser.InferSchema, then to parse the chunks with these types.
+func Scan(path string, chunkSize int, fn func(map[string][]any, []string) error, argOpts ...Option) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("scan: chunk size must be positive, got %d", chunkSize)
+	}
+
+	schema, err := inferSchema(path, argOpts)
 	if err != nil {
-		return nil, nil, err
+		return err
+	}
+
+	source, err := NewExcelSource(path, argOpts...)
+	if err != nil {
+		return err
 	}
+	defer source.Close()
 
-	data, err := parser.ParseRows(columns, rows)
+	opts := source.opts.parse
+	opts.Schema = append(opts.Schema[:len(opts.Schema):len(opts.Schema)], schema...)
+	return parser.Chunks(source, chunkSize, fn, opts)
+}
+
+func inferSchema(path string, argOpts []Option) (parser.Schema, error) {
+	source, err := NewExcelSource(path, argOpts...)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
+	defer source.Close()
 
-	return data, columns, nil
+	return parser.InferSchema(source, source.opts.parse)
 }
@@ -2,14 +2,26 @@ package excel
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/xuri/excelize/v2"
 )
 
+// excelTimeLayouts are the layouts Excelize renders the built-in Excel date formats with.
+var excelTimeLayouts = []string{
+	"01-02-06",
+	"2-Jan-06",
+	"1/2/06 15:04",
+}
+
 type ExcelSource struct {
 	path    string
 	rangeA1 string
 	opts    options
+
+	file  *excelize.File
+	rows  *excelize.Rows
+	width int
 }
 
 func NewExcelSource(path string, argOpts ...Option) (*ExcelSource, error) {
@@ -20,37 +32,89 @@ func NewExcelSource(path string, argOpts ...Option) (*ExcelSource, error) {
 		arg(&o)
 	}
 
+	o.parse.TimeLayouts = append(o.parse.TimeLayouts, excelTimeLayouts...)
+	o.parse.ExcelSerialDates = true
+
 	return &ExcelSource{
 		path:    path,
 		rangeA1: o.rangeA1,
+		opts:    o,
 	}, nil
 }
 
-func (s *ExcelSource) Read() ([]string, [][]string, error) {
+// Header opens the workbook and returns the first non-empty row of the sheet as column names.
+func (s *ExcelSource) Header() ([]string, error) {
 	// This file uses the Excelize library (github.com/qax-os/excelize), licensed under BSD 3-Clause.
 	f, err := excelize.OpenFile(s.path)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	defer f.Close()
+	s.file = f
 
 	sheet := s.rangeA1
 	if sheet == "" {
 		sheets := f.GetSheetList()
 		if len(sheets) == 0 {
-			return nil, nil, fmt.Errorf("excel: file has no sheets")
+			return nil, fmt.Errorf("excel: file has no sheets")
 		}
 		sheet = sheets[0]
 	}
 
-	rows, err := f.GetRows(sheet)
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	s.rows = rows
+
+	headers, err := s.Next()
+	if err == io.EOF {
+		return nil, fmt.Errorf("excel: sheet %s is empty", sheet)
+	}
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	s.width = len(headers)
+
+	return headers, nil
+}
+
+// Next returns the next non-empty row of the sheet, or io.EOF once the sheet is exhausted.
+//
+// Excel omits trailing empty cells, so short rows are padded with empty cells up to the width of the header.
+func (s *ExcelSource) Next() ([]string, error) {
+	for s.rows.Next() {
+		row, err := s.rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(row) == 0 {
+			continue
+		}
+
+		for len(row) < s.width {
+			row = append(row, "")
+		}
+
+		return row, nil
+	}
+
+	if err := s.rows.Error(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+// Close releases the sheet iterator and the underlying workbook.
+func (s *ExcelSource) Close() error {
+	if s.rows != nil {
+		s.rows.Close()
 	}
 
-	if len(rows) == 0 {
-		return nil, nil, fmt.Errorf("excel: sheet %s is empty", sheet)
+	if s.file != nil {
+		return s.file.Close()
 	}
 
-	return rows[0], rows[1:], nil
+	return nil
 }
@@ -0,0 +1,290 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Node is a node of the syntax tree of an expression.
+//
+// String returns a canonical representation of the node that parses back to the same tree.
+type Node interface {
+	Pos() int
+	String() string
+}
+
+// Literal is a constant: an int64, float64, string or bool, or nil for null.
+type Literal struct {
+	Value    any
+	Position int
+}
+
+// Ident is a reference to a column, optionally qualified by a table name as in orders.id.
+type Ident struct {
+	Qualifier string
+	Name      string
+	Position  int
+}
+
+// Unary is a prefix operator applied to X. Op is "-" or "!".
+type Unary struct {
+	Op       string
+	X        Node
+	Position int
+}
+
+// Binary is an infix operator applied to X and Y.
+//
+// Op is one of + - * / % ^ == != < <= > >= && ||. The aliases =, <>, and, or are normalized when parsing.
+type Binary struct {
+	Op       string
+	X, Y     Node
+	Position int
+}
+
+// Call is a function call. Distinct is set for calls written as f(DISTINCT x).
+type Call struct {
+	Name     string
+	Args     []Node
+	Distinct bool
+	Position int
+}
+
+// Star is the * argument of calls such as count(*).
+type Star struct {
+	Position int
+}
+
+// IsNull tests whether X is null, or not null when Not is set.
+type IsNull struct {
+	X        Node
+	Not      bool
+	Position int
+}
+
+// In tests whether X equals one of the values of List.
+type In struct {
+	X        Node
+	List     []Node
+	Not      bool
+	Position int
+}
+
+// Like matches the string X against a pattern where % matches any sequence of characters and _ matches a single character.
+type Like struct {
+	X, Pattern Node
+	Not        bool
+	Position   int
+}
+
+// Between tests whether Lo <= X <= Hi.
+type Between struct {
+	X, Lo, Hi Node
+	Not       bool
+	Position  int
+}
+
+func (n *Literal) Pos() int { return n.Position }
+func (n *Ident) Pos() int   { return n.Position }
+func (n *Unary) Pos() int   { return n.Position }
+func (n *Binary) Pos() int  { return n.Position }
+func (n *Call) Pos() int    { return n.Position }
+func (n *Star) Pos() int    { return n.Position }
+func (n *IsNull) Pos() int  { return n.Position }
+func (n *In) Pos() int      { return n.Position }
+func (n *Like) Pos() int    { return n.Position }
+func (n *Between) Pos() int { return n.Position }
+
+func (n *Literal) String() string {
+	switch v := n.Value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eEn") {
+			s += ".0"
+		}
+		return s
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return "?"
+	}
+}
+
+func (n *Ident) String() string {
+	name := quoteIdent(n.Name)
+	if n.Qualifier != "" {
+		return quoteIdent(n.Qualifier) + "." + name
+	}
+	return name
+}
+
+func (n *Unary) String() string {
+	return n.Op + paren(n.X)
+}
+
+func (n *Binary) String() string {
+	return paren(n.X) + " " + n.Op + " " + paren(n.Y)
+}
+
+func (n *Call) String() string {
+	args := make([]string, len(n.Args))
+	for i, a := range n.Args {
+		args[i] = a.String()
+	}
+
+	prefix := ""
+	if n.Distinct {
+		prefix = "distinct "
+	}
+	return n.Name + "(" + prefix + strings.Join(args, ", ") + ")"
+}
+
+func (n *Star) String() string {
+	return "*"
+}
+
+func (n *IsNull) String() string {
+	if n.Not {
+		return paren(n.X) + " is not null"
+	}
+	return paren(n.X) + " is null"
+}
+
+func (n *In) String() string {
+	items := make([]string, len(n.List))
+	for i, item := range n.List {
+		items[i] = item.String()
+	}
+	return paren(n.X) + not(n.Not) + " in (" + strings.Join(items, ", ") + ")"
+}
+
+func (n *Like) String() string {
+	return paren(n.X) + not(n.Not) + " like " + paren(n.Pattern)
+}
+
+func (n *Between) String() string {
+	return paren(n.X) + not(n.Not) + " between " + paren(n.Lo) + " and " + paren(n.Hi)
+}
+
+func not(negated bool) string {
+	if negated {
+		return " not"
+	}
+	return ""
+}
+
+// paren returns the representation of n, enclosed in parentheses unless n is an operand that never needs them.
+func paren(n Node) string {
+	switch n.(type) {
+	case *Literal, *Ident, *Call, *Star:
+		return n.String()
+	default:
+		return "(" + n.String() + ")"
+	}
+}
+
+func quoteIdent(name string) string {
+	plain := name != "" && !isKeyword(name)
+	for i, r := range name {
+		if r != '_' && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(i > 0 && r >= '0' && r <= '9') && r < 0x80 {
+			plain = false
+			break
+		}
+	}
+
+	if plain {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// Walk calls f for n and every node below it, in depth-first order. Children are not visited if f returns false.
+func Walk(n Node, f func(Node) bool) {
+	if n == nil || !f(n) {
+		return
+	}
+
+	switch n := n.(type) {
+	case *Unary:
+		Walk(n.X, f)
+	case *Binary:
+		Walk(n.X, f)
+		Walk(n.Y, f)
+	case *Call:
+		for _, a := range n.Args {
+			Walk(a, f)
+		}
+	case *IsNull:
+		Walk(n.X, f)
+	case *In:
+		Walk(n.X, f)
+		for _, item := range n.List {
+			Walk(item, f)
+		}
+	case *Like:
+		Walk(n.X, f)
+		Walk(n.Pattern, f)
+	case *Between:
+		Walk(n.X, f)
+		Walk(n.Lo, f)
+		Walk(n.Hi, f)
+	}
+}
+
+// Rewrite returns a copy of the tree rooted at n where every node for which f returns a replacement is substituted. Children of a replaced node are not visited.
+func Rewrite(n Node, f func(Node) (Node, bool)) Node {
+	if n == nil {
+		return nil
+	}
+	if r, ok := f(n); ok {
+		return r
+	}
+
+	rewriteAll := func(nodes []Node) []Node {
+		result := make([]Node, len(nodes))
+		for i, item := range nodes {
+			result[i] = Rewrite(item, f)
+		}
+		return result
+	}
+
+	switch n := n.(type) {
+	case *Unary:
+		c := *n
+		c.X = Rewrite(n.X, f)
+		return &c
+	case *Binary:
+		c := *n
+		c.X, c.Y = Rewrite(n.X, f), Rewrite(n.Y, f)
+		return &c
+	case *Call:
+		c := *n
+		c.Args = rewriteAll(n.Args)
+		return &c
+	case *IsNull:
+		c := *n
+		c.X = Rewrite(n.X, f)
+		return &c
+	case *In:
+		c := *n
+		c.X = Rewrite(n.X, f)
+		c.List = rewriteAll(n.List)
+		return &c
+	case *Like:
+		c := *n
+		c.X, c.Pattern = Rewrite(n.X, f), Rewrite(n.Pattern, f)
+		return &c
+	case *Between:
+		c := *n
+		c.X, c.Lo, c.Hi = Rewrite(n.X, f), Rewrite(n.Lo, f), Rewrite(n.Hi, f)
+		return &c
+	default:
+		return n
+	}
+}
@@ -0,0 +1,642 @@
+package expr
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Row gives access to the values of the row being evaluated, by the column index returned from a Resolver.
+type Row func(index int) any
+
+// Resolver looks up a column referenced by an expression, and returns the index passed to Row together with the kind of its values.
+//
+// The qualifier is empty unless the reference was written as qualifier.name.
+type Resolver func(qualifier, name string) (index int, kind Kind, err error)
+
+type evalFunc func(Row) (any, error)
+
+// Program is a compiled expression, ready to be evaluated against rows.
+type Program struct {
+	kind Kind
+	eval evalFunc
+}
+
+// Kind returns the static kind of the values produced by the program.
+func (p *Program) Kind() Kind {
+	return p.kind
+}
+
+// Eval evaluates the program against a row. A nil result is a null value.
+func (p *Program) Eval(row Row) (any, error) {
+	return p.eval(row)
+}
+
+// Compile type-checks the expression n, resolving column references with resolve, and returns a Program evaluating it.
+//
+// Operations whose operands have a known kind are checked here, so an error such as adding a string to a number is reported before any row is evaluated. Operands of KindUnknown are checked during evaluation.
+func Compile(n Node, resolve Resolver) (*Program, error) {
+	c := &compiler{resolve: resolve}
+
+	eval, kind, err := c.compile(n)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{kind: kind, eval: eval}, nil
+}
+
+type compiler struct {
+	resolve Resolver
+}
+
+func (c *compiler) compile(n Node) (evalFunc, Kind, error) {
+	switch n := n.(type) {
+	case *Literal:
+		v := n.Value
+		return func(Row) (any, error) { return v, nil }, kindOf(v), nil
+	case *Ident:
+		index, kind, err := c.resolve(n.Qualifier, n.Name)
+		if err != nil {
+			return nil, 0, err
+		}
+		return func(row Row) (any, error) { return normalize(row(index)), nil }, kind, nil
+	case *Unary:
+		return c.compileUnary(n)
+	case *Binary:
+		return c.compileBinary(n)
+	case *Call:
+		return c.compileCall(n)
+	case *IsNull:
+		return c.compileIsNull(n)
+	case *In:
+		return c.compileIn(n)
+	case *Like:
+		return c.compileLike(n)
+	case *Between:
+		return c.compileBetween(n)
+	case *Star:
+		return nil, 0, fmt.Errorf("expr: unexpected * at position %d", n.Position)
+	default:
+		return nil, 0, fmt.Errorf("expr: unsupported node %T", n)
+	}
+}
+
+func (c *compiler) compileUnary(n *Unary) (evalFunc, Kind, error) {
+	x, kind, err := c.compile(n.X)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if n.Op == "!" {
+		if !kind.dynamic() && kind != KindBool {
+			return nil, 0, fmt.Errorf("expr: operator ! not defined for %s at position %d", kind, n.Position)
+		}
+
+		return func(row Row) (any, error) {
+			v, err := x(row)
+			if err != nil || v == nil {
+				return nil, err
+			}
+
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("expr: operator ! not defined for %s", typeName(v))
+			}
+			return !b, nil
+		}, KindBool, nil
+	}
+
+	if !kind.dynamic() && !kind.numeric() {
+		return nil, 0, fmt.Errorf("expr: operator - not defined for %s at position %d", kind, n.Position)
+	}
+
+	return func(row Row) (any, error) {
+		v, err := x(row)
+		if err != nil || v == nil {
+			return nil, err
+		}
+
+		switch x := v.(type) {
+		case int64:
+			if x == math.MinInt64 {
+				return nil, fmt.Errorf("expr: integer overflow in -(%d)", x)
+			}
+			return -x, nil
+		case float64:
+			return -x, nil
+		default:
+			return nil, fmt.Errorf("expr: operator - not defined for %s", typeName(v))
+		}
+	}, kind, nil
+}
+
+func (c *compiler) compileBinary(n *Binary) (evalFunc, Kind, error) {
+	x, kx, err := c.compile(n.X)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	y, ky, err := c.compile(n.Y)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch n.Op {
+	case "&&", "||":
+		for _, k := range []Kind{kx, ky} {
+			if !k.dynamic() && k != KindBool {
+				return nil, 0, fmt.Errorf("expr: operator %s not defined for %s at position %d", n.Op, k, n.Position)
+			}
+		}
+		return logical(n.Op, x, y), KindBool, nil
+	case "==", "!=", "<", "<=", ">", ">=":
+		x, kx, y, ky, err = c.timeLiterals(n.X, x, kx, n.Y, y, ky)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !comparable(kx, ky) {
+			return nil, 0, fmt.Errorf("expr: cannot compare %s and %s at position %d", kx, ky, n.Position)
+		}
+
+		op := n.Op
+		return func(row Row) (any, error) {
+			a, err := x(row)
+			if err != nil {
+				return nil, err
+			}
+			b, err := y(row)
+			if err != nil {
+				return nil, err
+			}
+			return compareOp(op, a, b)
+		}, KindBool, nil
+	default:
+		kind, err := arithKind(n.Op, kx, ky)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w at position %d", err, n.Position)
+		}
+
+		op := n.Op
+		return func(row Row) (any, error) {
+			a, err := x(row)
+			if err != nil {
+				return nil, err
+			}
+			b, err := y(row)
+			if err != nil {
+				return nil, err
+			}
+			return arith(op, a, b)
+		}, kind, nil
+	}
+}
+
+// timeLiterals converts a string literal compared with a time operand into a time, so that conditions such as date >= '2024-01-31' work.
+func (c *compiler) timeLiterals(nx Node, x evalFunc, kx Kind, ny Node, y evalFunc, ky Kind) (evalFunc, Kind, evalFunc, Kind, error) {
+	convert := func(n Node) (evalFunc, error) {
+		s := n.(*Literal).Value.(string)
+		t, err := ParseTime(s)
+		if err != nil {
+			return nil, fmt.Errorf("expr: cannot parse %q as time at position %d", s, n.Pos())
+		}
+		return func(Row) (any, error) { return t, nil }, nil
+	}
+
+	var err error
+	if kx == KindTime && ky == KindString && isLiteral(ny) {
+		y, err = convert(ny)
+		ky = KindTime
+	} else if ky == KindTime && kx == KindString && isLiteral(nx) {
+		x, err = convert(nx)
+		kx = KindTime
+	}
+	return x, kx, y, ky, err
+}
+
+func isLiteral(n Node) bool {
+	_, ok := n.(*Literal)
+	return ok
+}
+
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", time.DateTime, time.DateOnly}
+
+// ParseTime parses s with the layouts accepted in time comparisons: RFC 3339, and ISO 8601 dates with or without a time of day.
+func ParseTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func comparable(a, b Kind) bool {
+	return a.dynamic() || b.dynamic() || a == b || (a.numeric() && b.numeric())
+}
+
+func arithKind(op string, a, b Kind) (Kind, error) {
+	if a.dynamic() || b.dynamic() {
+		return KindUnknown, nil
+	}
+
+	switch {
+	case op == "+" && a == KindString && b == KindString:
+		return KindString, nil
+	case a.numeric() && b.numeric():
+		if a == KindInt && b == KindInt && op != "/" && op != "^" {
+			return KindInt, nil
+		}
+		return KindFloat, nil
+	default:
+		return 0, fmt.Errorf("expr: operator %s not defined for %s and %s", op, a, b)
+	}
+}
+
+// arith applies an arithmetic operator. Integer operands give an integer result except for / and ^, and an error if it overflows int64. Division by zero gives null.
+func arith(op string, a, b any) (any, error) {
+	if a == nil || b == nil {
+		return nil, nil
+	}
+
+	if sa, ok := a.(string); ok && op == "+" {
+		if sb, ok := b.(string); ok {
+			return sa + sb, nil
+		}
+	}
+
+	ia, aInt := a.(int64)
+	ib, bInt := b.(int64)
+	if aInt && bInt {
+		switch op {
+		case "+", "-", "*":
+			r, ok := intArith(op, ia, ib)
+			if !ok {
+				return nil, fmt.Errorf("expr: integer overflow in %d %s %d", ia, op, ib)
+			}
+			return r, nil
+		case "%":
+			if ib == 0 {
+				return nil, nil
+			}
+			return ia % ib, nil
+		}
+	}
+
+	fa, okA := toFloat(a)
+	fb, okB := toFloat(b)
+	if !okA || !okB {
+		return nil, fmt.Errorf("expr: operator %s not defined for %s and %s", op, typeName(a), typeName(b))
+	}
+
+	switch op {
+	case "+":
+		return fa + fb, nil
+	case "-":
+		return fa - fb, nil
+	case "*":
+		return fa * fb, nil
+	case "/":
+		if fb == 0 {
+			return nil, nil
+		}
+		return fa / fb, nil
+	case "%":
+		if fb == 0 {
+			return nil, nil
+		}
+		return math.Mod(fa, fb), nil
+	case "^":
+		return math.Pow(fa, fb), nil
+	default:
+		return nil, fmt.Errorf("expr: unknown operator %s", op)
+	}
+}
+
+// intArith applies +, - or * to integers. It reports false if the result overflows int64.
+func intArith(op string, a, b int64) (int64, bool) {
+	switch op {
+	case "+":
+		r := a + b
+		return r, (r > a) == (b > 0)
+	case "-":
+		r := a - b
+		return r, (r < a) == (b > 0)
+	default:
+		if a == 0 || b == 0 {
+			return 0, true
+		}
+		r := a * b
+		return r, r/b == a && !(b == -1 && a == math.MinInt64)
+	}
+}
+
+func toFloat(v any) (float64, bool) {
+	switch x := v.(type) {
+	case int64:
+		return float64(x), true
+	case float64:
+		return x, true
+	default:
+		return 0, false
+	}
+}
+
+// Compare returns -1, 0 or +1 depending on whether a is less than, equal to or greater than b. It reports false if the values cannot be compared.
+//
+// Numbers are compared by value whatever their type, strings lexicographically, times chronologically and false before true.
+func Compare(a, b any) (int, bool) {
+	a, b = normalize(a), normalize(b)
+
+	if ia, ok := a.(int64); ok {
+		if ib, ok := b.(int64); ok {
+			return cmp.Compare(ia, ib), true
+		}
+	}
+
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			return cmp.Compare(fa, fb), true
+		}
+		return 0, false
+	}
+
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0, true
+			case !x:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y), true
+		}
+	}
+
+	return 0, false
+}
+
+func compareOp(op string, a, b any) (any, error) {
+	if a == nil || b == nil {
+		return nil, nil
+	}
+
+	c, ok := Compare(a, b)
+	if !ok {
+		switch op {
+		case "==":
+			return false, nil
+		case "!=":
+			return true, nil
+		default:
+			return nil, fmt.Errorf("expr: cannot compare %s and %s", typeName(a), typeName(b))
+		}
+	}
+
+	switch op {
+	case "==":
+		return c == 0, nil
+	case "!=":
+		return c != 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default:
+		return c >= 0, nil
+	}
+}
+
+// logical implements && and || with three-valued logic: null && false is false and null || true is true, other combinations with null are null.
+func logical(op string, x, y evalFunc) evalFunc {
+	// decisive is the operand value that determines the result on its own.
+	decisive := op == "||"
+
+	return func(row Row) (any, error) {
+		a, err := evalBool(x, row, op)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil && a.(bool) == decisive {
+			return decisive, nil
+		}
+
+		b, err := evalBool(y, row, op)
+		if err != nil {
+			return nil, err
+		}
+		if b != nil && b.(bool) == decisive {
+			return decisive, nil
+		}
+
+		if a == nil || b == nil {
+			return nil, nil
+		}
+		return !decisive, nil
+	}
+}
+
+func evalBool(f evalFunc, row Row, op string) (any, error) {
+	v, err := f(row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	if _, ok := v.(bool); !ok {
+		return nil, fmt.Errorf("expr: operator %s not defined for %s", op, typeName(v))
+	}
+	return v, nil
+}
+
+func (c *compiler) compileIsNull(n *IsNull) (evalFunc, Kind, error) {
+	x, _, err := c.compile(n.X)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	not := n.Not
+	return func(row Row) (any, error) {
+		v, err := x(row)
+		if err != nil {
+			return nil, err
+		}
+		return (v == nil) != not, nil
+	}, KindBool, nil
+}
+
+func (c *compiler) compileIn(n *In) (evalFunc, Kind, error) {
+	x, kx, err := c.compile(n.X)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]evalFunc, len(n.List))
+	for i, item := range n.List {
+		eval, kind, err := c.compile(item)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !comparable(kx, kind) {
+			return nil, 0, fmt.Errorf("expr: cannot compare %s and %s at position %d", kx, kind, item.Pos())
+		}
+		items[i] = eval
+	}
+
+	not := n.Not
+	return func(row Row) (any, error) {
+		v, err := x(row)
+		if err != nil || v == nil {
+			return nil, err
+		}
+
+		sawNull := false
+		for _, item := range items {
+			w, err := item(row)
+			if err != nil {
+				return nil, err
+			}
+			if w == nil {
+				sawNull = true
+				continue
+			}
+			if c, ok := Compare(v, w); ok && c == 0 {
+				return !not, nil
+			}
+		}
+
+		if sawNull {
+			return nil, nil
+		}
+		return not, nil
+	}, KindBool, nil
+}
+
+func (c *compiler) compileLike(n *Like) (evalFunc, Kind, error) {
+	x, kx, err := c.compile(n.X)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pattern, kp, err := c.compile(n.Pattern)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, k := range []Kind{kx, kp} {
+		if !k.dynamic() && k != KindString {
+			return nil, 0, fmt.Errorf("expr: operator like not defined for %s at position %d", k, n.Position)
+		}
+	}
+
+	var fixed *regexp.Regexp
+	if lit, ok := n.Pattern.(*Literal); ok {
+		if s, ok := lit.Value.(string); ok {
+			fixed = likeRegexp(s)
+		}
+	}
+
+	not := n.Not
+	return func(row Row) (any, error) {
+		v, err := x(row)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		p, err := pattern(row)
+		if err != nil || p == nil {
+			return nil, err
+		}
+
+		s, ok1 := v.(string)
+		ps, ok2 := p.(string)
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("expr: operator like not defined for %s and %s", typeName(v), typeName(p))
+		}
+
+		re := fixed
+		if re == nil {
+			re = likeRegexp(ps)
+		}
+		return re.MatchString(s) != not, nil
+	}, KindBool, nil
+}
+
+func likeRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?s)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+func (c *compiler) compileBetween(n *Between) (evalFunc, Kind, error) {
+	x, kx, err := c.compile(n.X)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lo, klo, err := c.compile(n.Lo)
+	if err != nil {
+		return nil, 0, err
+	}
+	x, kx, lo, klo, err = c.timeLiterals(n.X, x, kx, n.Lo, lo, klo)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hi, khi, err := c.compile(n.Hi)
+	if err != nil {
+		return nil, 0, err
+	}
+	x, kx, hi, khi, err = c.timeLiterals(n.X, x, kx, n.Hi, hi, khi)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !comparable(kx, klo) || !comparable(kx, khi) {
+		return nil, 0, fmt.Errorf("expr: cannot compare %s with %s and %s at position %d", kx, klo, khi, n.Position)
+	}
+
+	not := n.Not
+	return func(row Row) (any, error) {
+		values := make([]any, 3)
+		for i, f := range []evalFunc{x, lo, hi} {
+			v, err := f(row)
+			if err != nil || v == nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+
+		above, ok1 := Compare(values[0], values[1])
+		below, ok2 := Compare(values[0], values[2])
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("expr: cannot compare %s with %s and %s", typeName(values[0]), typeName(values[1]), typeName(values[2]))
+		}
+		return (above >= 0 && below <= 0) != not, nil
+	}, KindBool, nil
+}
@@ -0,0 +1,232 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testColumns are the columns available to the expressions under test, with their kind and value.
+var testColumns = []struct {
+	name  string
+	kind  Kind
+	value any
+}{
+	{"i", KindInt, int64(7)},
+	{"f", KindFloat, 2.5},
+	{"s", KindString, "Ann"},
+	{"b", KindBool, true},
+	{"t", KindTime, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+	{"n", KindInt, nil},
+	{"u", KindUnknown, "mixed"},
+	{"big", KindInt, int64(math.MaxInt64)},
+	{"first name", KindString, "Bo"},
+}
+
+func resolveTest(qualifier, name string) (int, Kind, error) {
+	for i, c := range testColumns {
+		if c.name == name {
+			return i, c.kind, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("expr: column %s not found", name)
+}
+
+func compileTest(src string) (*Program, error) {
+	n, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(n, resolveTest)
+}
+
+func evalTest(src string) (any, Kind, error) {
+	p, err := compileTest(src)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	v, err := p.Eval(func(i int) any { return testColumns[i].value })
+	return v, p.Kind(), err
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		src  string
+		want any
+		kind Kind
+	}{
+		{"1 + 2 * 3", int64(7), KindInt},
+		{"(1 + 2) * 3", int64(9), KindInt},
+		{"7 / 2", 3.5, KindFloat},
+		{"2 ^ 3", 8.0, KindFloat},
+		{"i % 4", int64(3), KindInt},
+		{"i / 0", nil, KindFloat},
+		{"i % 0", nil, KindInt},
+		{"-i", int64(-7), KindInt},
+		{"i + f", 9.5, KindFloat},
+		{"s + '!'", "Ann!", KindString},
+		{"`first name` == 'Bo'", true, KindBool},
+		{"i >= 7 and s = 'Ann'", true, KindBool},
+		{"i <> 7 or not b", false, KindBool},
+		{"n > 1", nil, KindBool},
+		{"n > 1 and false", false, KindBool},
+		{"n > 1 or true", true, KindBool},
+		{"n is null", true, KindBool},
+		{"i is not null", true, KindBool},
+		{"i between 1 and 7", true, KindBool},
+		{"s in ('Bob', 'Ann')", true, KindBool},
+		{"s like 'A%'", true, KindBool},
+		{"s like '_n'", false, KindBool},
+		{"t > '2024-01-31'", true, KindBool},
+		{"u == 1", false, KindBool},
+		{"u != 1", true, KindBool},
+		{"lower(s) + upper(s)", "annANN", KindString},
+		{"len(trim('  ab '))", int64(2), KindInt},
+		{"substr('hello', 2, 3)", "ell", KindString},
+		{"replace(s, 'n', 'x')", "Axx", KindString},
+		{"concat(s, i, null)", "Ann7", KindString},
+		{"round(f * 1.11, 1)", 2.8, KindFloat},
+		{"abs(-i)", int64(7), KindInt},
+		{"pow(2, 10)", 1024.0, KindFloat},
+		{"sqrt(-1)", nil, KindFloat},
+		{"coalesce(n, i)", int64(7), KindInt},
+		{"coalesce(n, f, i)", 2.5, KindFloat},
+		{"coalesce(n, 1.5)", 1.5, KindFloat},
+		{"isnull(n)", true, KindBool},
+		{"if(b, i, f)", 7.0, KindFloat},
+		{"if(n > 1, 'yes', 'no')", "no", KindString},
+		{"if(b, null, 'x')", nil, KindString},
+		{"if(b, u, 1)", "mixed", KindUnknown},
+		{"year(t) * 100 + month(t)", int64(202403), KindInt},
+		{"big - 1", int64(math.MaxInt64 - 1), KindInt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			got, kind, err := evalTest(tt.src)
+			if err != nil {
+				t.Fatalf("eval: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("value = %#v, want %#v", got, tt.want)
+			}
+			if kind != tt.kind {
+				t.Errorf("kind = %v, want %v", kind, tt.kind)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"1 +",
+		"(1 + 2",
+		"i == == 1",
+		"'unterminated",
+		"`unterminated",
+		"i in (1, 2",
+		"i between 1",
+		"1 2",
+		"and",
+		"f(,)",
+	}
+
+	for _, src := range tests {
+		t.Run(src, func(t *testing.T) {
+			if n, err := Parse(src); err == nil {
+				t.Fatalf("Parse(%q) = %v, want an error", src, n)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"missing > 1", "column missing not found"},
+		{"s + 1", "operator + not defined for string and int"},
+		{"s > 1", "cannot compare string and int"},
+		{"b < 1", "cannot compare bool and int"},
+		{"-s", "operator - not defined for string"},
+		{"not i", "operator ! not defined for int"},
+		{"i and b", "operator && not defined for int"},
+		{"nope(1)", "unknown function nope"},
+		{"lower(s, s)", "function lower expects 1 argument, got 2"},
+		{"substr(s)", "function substr expects 2 to 3 arguments, got 1"},
+		{"lower(i)", "function lower expects string for argument 1, got int"},
+		{"sqrt(s)", "function sqrt expects number for argument 1, got string"},
+		{"if(i, 1, 2)", "function if expects bool for argument 1, got int"},
+		{"if(b, 1, 'x')", "function if expects arguments of the same kind, got int and string"},
+		{"if(b, t, s)", "function if expects arguments of the same kind, got time and string"},
+		{"coalesce(n, i, 'x')", "function coalesce expects arguments of the same kind, got int and string"},
+		{"year(s)", "function year expects time for argument 1, got string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := compileTest(tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"big + 1", "integer overflow in 9223372036854775807 + 1"},
+		{"-big - 2", "integer overflow in -9223372036854775807 - 2"},
+		{"big * 2", "integer overflow in 9223372036854775807 * 2"},
+		{"-(-big - 1)", "integer overflow in -(-9223372036854775808)"},
+		{"u + 1", "operator + not defined for string and int"},
+		{"u > 1", "cannot compare string and int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, _, err := evalTest(tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntArith(t *testing.T) {
+	tests := []struct {
+		op   string
+		a, b int64
+		want int64
+		ok   bool
+	}{
+		{"+", math.MaxInt64, 0, math.MaxInt64, true},
+		{"+", math.MaxInt64, 1, 0, false},
+		{"+", math.MinInt64, -1, 0, false},
+		{"-", math.MinInt64, 1, 0, false},
+		{"-", 0, math.MinInt64, 0, false},
+		{"-", -1, math.MinInt64, math.MaxInt64, true},
+		{"*", math.MinInt64, -1, 0, false},
+		{"*", -1, math.MinInt64, 0, false},
+		{"*", math.MinInt64, 1, math.MinInt64, true},
+		{"*", 1 << 32, 1 << 31, 0, false},
+		{"*", 1 << 31, 1 << 31, 1 << 62, true},
+		{"*", 0, math.MinInt64, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := intArith(tt.op, tt.a, tt.b)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("intArith(%d %s %d) = %d, %v, want %d, %v", tt.a, tt.op, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Pseudo kinds used to describe function parameters.
+const (
+	kindAny    Kind = -1
+	kindNumber Kind = -2
+)
+
+type function struct {
+	// params is the expected kind of each parameter. With variadic set, the last one may repeat.
+	params   []Kind
+	variadic bool
+	// optional is the number of trailing parameters that may be omitted.
+	optional int
+	// strict functions return null as soon as an argument is null, without being called.
+	strict bool
+	// unify requires the arguments accepting any kind to share a common kind, because the result is one of them.
+	unify bool
+	// result returns the kind of the result from the kinds of the arguments.
+	result func(args []Kind) Kind
+	call   func(args []any, result Kind) (any, error)
+}
+
+func returns(k Kind) func([]Kind) Kind {
+	return func([]Kind) Kind { return k }
+}
+
+func stringFunc(f func(string) any) func([]any, Kind) (any, error) {
+	return func(args []any, _ Kind) (any, error) {
+		s, err := asString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return f(s), nil
+	}
+}
+
+func stringPairFunc(f func(s, t string) any) func([]any, Kind) (any, error) {
+	return func(args []any, _ Kind) (any, error) {
+		s, err := asString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		t, err := asString(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return f(s, t), nil
+	}
+}
+
+func floatFunc(f func(float64) float64) func([]any, Kind) (any, error) {
+	return func(args []any, _ Kind) (any, error) {
+		x, err := asFloat(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return finite(f(x)), nil
+	}
+}
+
+func timeFunc(f func(time.Time) int) func([]any, Kind) (any, error) {
+	return func(args []any, _ Kind) (any, error) {
+		t, ok := args[0].(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("expected time, got %s", typeName(args[0]))
+		}
+		return int64(f(t)), nil
+	}
+}
+
+// functions lists the functions available in expressions, by lower-case name.
+var functions = map[string]function{
+	"lower":      {params: []Kind{KindString}, strict: true, result: returns(KindString), call: stringFunc(func(s string) any { return strings.ToLower(s) })},
+	"upper":      {params: []Kind{KindString}, strict: true, result: returns(KindString), call: stringFunc(func(s string) any { return strings.ToUpper(s) })},
+	"trim":       {params: []Kind{KindString}, strict: true, result: returns(KindString), call: stringFunc(func(s string) any { return strings.TrimSpace(s) })},
+	"len":        {params: []Kind{KindString}, strict: true, result: returns(KindInt), call: stringFunc(func(s string) any { return int64(utf8.RuneCountInString(s)) })},
+	"length":     {params: []Kind{KindString}, strict: true, result: returns(KindInt), call: stringFunc(func(s string) any { return int64(utf8.RuneCountInString(s)) })},
+	"contains":   {params: []Kind{KindString, KindString}, strict: true, result: returns(KindBool), call: stringPairFunc(func(s, t string) any { return strings.Contains(s, t) })},
+	"startswith": {params: []Kind{KindString, KindString}, strict: true, result: returns(KindBool), call: stringPairFunc(func(s, t string) any { return strings.HasPrefix(s, t) })},
+	"endswith":   {params: []Kind{KindString, KindString}, strict: true, result: returns(KindBool), call: stringPairFunc(func(s, t string) any { return strings.HasSuffix(s, t) })},
+	"replace": {params: []Kind{KindString, KindString, KindString}, strict: true, result: returns(KindString), call: func(args []any, _ Kind) (any, error) {
+		strs := make([]string, 3)
+		for i, a := range args {
+			s, err := asString(a)
+			if err != nil {
+				return nil, err
+			}
+			strs[i] = s
+		}
+		return strings.ReplaceAll(strs[0], strs[1], strs[2]), nil
+	}},
+	"substr": {params: []Kind{KindString, KindInt, KindInt}, optional: 1, strict: true, result: returns(KindString), call: substr},
+	"concat": {params: []Kind{kindAny}, variadic: true, result: returns(KindString), call: func(args []any, _ Kind) (any, error) {
+		var b strings.Builder
+		for _, a := range args {
+			b.WriteString(format(a))
+		}
+		return b.String(), nil
+	}},
+
+	"abs": {params: []Kind{kindNumber}, strict: true, result: func(args []Kind) Kind { return args[0] }, call: func(args []any, _ Kind) (any, error) {
+		if n, ok := args[0].(int64); ok {
+			if n < 0 {
+				return -n, nil
+			}
+			return n, nil
+		}
+		x, err := asFloat(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return math.Abs(x), nil
+	}},
+	"round": {params: []Kind{kindNumber, KindInt}, optional: 1, strict: true, result: returns(KindFloat), call: func(args []any, _ Kind) (any, error) {
+		x, err := asFloat(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		digits := int64(0)
+		if len(args) > 1 {
+			d, ok := args[1].(int64)
+			if !ok {
+				return nil, fmt.Errorf("expected int, got %s", typeName(args[1]))
+			}
+			digits = d
+		}
+
+		p := math.Pow(10, float64(digits))
+		return finite(math.Round(x*p) / p), nil
+	}},
+	"floor": {params: []Kind{kindNumber}, strict: true, result: returns(KindFloat), call: floatFunc(math.Floor)},
+	"ceil":  {params: []Kind{kindNumber}, strict: true, result: returns(KindFloat), call: floatFunc(math.Ceil)},
+	"sqrt":  {params: []Kind{kindNumber}, strict: true, result: returns(KindFloat), call: floatFunc(math.Sqrt)},
+	"log":   {params: []Kind{kindNumber}, strict: true, result: returns(KindFloat), call: floatFunc(math.Log)},
+	"exp":   {params: []Kind{kindNumber}, strict: true, result: returns(KindFloat), call: floatFunc(math.Exp)},
+	"pow": {params: []Kind{kindNumber, kindNumber}, strict: true, result: returns(KindFloat), call: func(args []any, _ Kind) (any, error) {
+		x, err := asFloat(args[0])
+		if err != nil {
+			return nil, err
+		}
+		y, err := asFloat(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return finite(math.Pow(x, y)), nil
+	}},
+
+	"coalesce": {params: []Kind{kindAny}, variadic: true, result: commonKind, unify: true, call: func(args []any, result Kind) (any, error) {
+		for _, a := range args {
+			if a != nil {
+				return promote(a, result), nil
+			}
+		}
+		return nil, nil
+	}},
+	"isnull": {params: []Kind{kindAny}, result: returns(KindBool), call: func(args []any, _ Kind) (any, error) {
+		return args[0] == nil, nil
+	}},
+	"if": {params: []Kind{KindBool, kindAny, kindAny}, result: func(args []Kind) Kind { return commonKind(args[1:]) }, unify: true, call: func(args []any, result Kind) (any, error) {
+		switch cond := args[0].(type) {
+		case nil:
+			return promote(args[2], result), nil
+		case bool:
+			if cond {
+				return promote(args[1], result), nil
+			}
+			return promote(args[2], result), nil
+		default:
+			return nil, fmt.Errorf("expected bool, got %s", typeName(args[0]))
+		}
+	}},
+
+	"year":  {params: []Kind{KindTime}, strict: true, result: returns(KindInt), call: timeFunc(func(t time.Time) int { return t.Year() })},
+	"month": {params: []Kind{KindTime}, strict: true, result: returns(KindInt), call: timeFunc(func(t time.Time) int { return int(t.Month()) })},
+	"day":   {params: []Kind{KindTime}, strict: true, result: returns(KindInt), call: timeFunc(func(t time.Time) int { return t.Day() })},
+}
+
+// IsFunction reports whether name is a function available in expressions.
+func IsFunction(name string) bool {
+	_, ok := functions[strings.ToLower(name)]
+	return ok
+}
+
+func (c *compiler) compileCall(n *Call) (evalFunc, Kind, error) {
+	f, ok := functions[n.Name]
+	if !ok {
+		return nil, 0, fmt.Errorf("expr: unknown function %s at position %d", n.Name, n.Position)
+	}
+	if n.Distinct {
+		return nil, 0, fmt.Errorf("expr: distinct is not allowed in %s at position %d", n.Name, n.Position)
+	}
+
+	minArgs := len(f.params) - f.optional
+	if len(n.Args) < minArgs || (!f.variadic && len(n.Args) > len(f.params)) {
+		return nil, 0, fmt.Errorf("expr: function %s expects %s, got %d at position %d", n.Name, arity(f), len(n.Args), n.Position)
+	}
+
+	args := make([]evalFunc, len(n.Args))
+	kinds := make([]Kind, len(n.Args))
+	for i, a := range n.Args {
+		eval, kind, err := c.compile(a)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		expected := f.params[min(i, len(f.params)-1)]
+		if !accepts(expected, kind) {
+			return nil, 0, fmt.Errorf("expr: function %s expects %s for argument %d, got %s at position %d", n.Name, kindName(expected), i+1, kind, a.Pos())
+		}
+
+		args[i] = eval
+		kinds[i] = kind
+	}
+
+	if f.unify {
+		if err := unifyArgs(n, f, kinds); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	result := f.result(kinds)
+	name := n.Name
+
+	return func(row Row) (any, error) {
+		values := make([]any, len(args))
+		for i, arg := range args {
+			v, err := arg(row)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil && f.strict {
+				return nil, nil
+			}
+			values[i] = v
+		}
+
+		v, err := f.call(values, result)
+		if err != nil {
+			return nil, fmt.Errorf("expr: function %s: %w", name, err)
+		}
+		return v, nil
+	}, result, nil
+}
+
+func arity(f function) string {
+	n := len(f.params)
+	switch {
+	case f.variadic:
+		return "at least 1 argument"
+	case f.optional > 0:
+		return fmt.Sprintf("%d to %d arguments", n-f.optional, n)
+	case n == 1:
+		return "1 argument"
+	default:
+		return fmt.Sprintf("%d arguments", n)
+	}
+}
+
+func accepts(expected, actual Kind) bool {
+	switch {
+	case expected == kindAny || actual.dynamic():
+		return true
+	case expected == kindNumber:
+		return actual.numeric()
+	case expected == KindFloat:
+		return actual.numeric()
+	default:
+		return expected == actual
+	}
+}
+
+func kindName(k Kind) string {
+	switch k {
+	case kindAny:
+		return "any value"
+	case kindNumber:
+		return "number"
+	default:
+		return k.String()
+	}
+}
+
+// unifyArgs returns an error if two arguments of n accepting any kind have static kinds without a common kind. Integers and floats unify to float.
+func unifyArgs(n *Call, f function, kinds []Kind) error {
+	first := -1
+	for i, k := range kinds {
+		if f.params[min(i, len(f.params)-1)] != kindAny || k.dynamic() {
+			continue
+		}
+		if first < 0 {
+			first = i
+			continue
+		}
+		if commonKind([]Kind{kinds[first], k}) == KindUnknown {
+			return fmt.Errorf("expr: function %s expects arguments of the same kind, got %s and %s at position %d", n.Name, kinds[first], k, n.Args[i].Pos())
+		}
+	}
+	return nil
+}
+
+// commonKind returns the kind shared by the non-null kinds, float if they are all numeric, or unknown if they differ.
+func commonKind(kinds []Kind) Kind {
+	result := KindNull
+	for _, k := range kinds {
+		switch {
+		case k == KindNull:
+		case result == KindNull || result == k:
+			result = k
+		case result.numeric() && k.numeric():
+			result = KindFloat
+		default:
+			return KindUnknown
+		}
+	}
+	return result
+}
+
+// promote converts an integer to float64 when the result of a function is a float, so that its values share one type.
+func promote(v any, result Kind) any {
+	if n, ok := v.(int64); ok && result == KindFloat {
+		return float64(n)
+	}
+	return v
+}
+
+func substr(args []any, _ Kind) (any, error) {
+	s, err := asString(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	start, ok := args[1].(int64)
+	if !ok {
+		return nil, fmt.Errorf("expected int, got %s", typeName(args[1]))
+	}
+
+	runes := []rune(s)
+	from := int(max(start, 1)) - 1
+	if from > len(runes) {
+		from = len(runes)
+	}
+
+	to := len(runes)
+	if len(args) > 2 {
+		length, ok := args[2].(int64)
+		if !ok {
+			return nil, fmt.Errorf("expected int, got %s", typeName(args[2]))
+		}
+		if length < 0 {
+			length = 0
+		}
+		to = min(from+int(length), len(runes))
+	}
+
+	return string(runes[from:to]), nil
+}
+
+func asString(v any) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expected string, got %s", typeName(v))
+	}
+	return s, nil
+}
+
+func asFloat(v any) (float64, error) {
+	x, ok := toFloat(v)
+	if !ok {
+		return 0, fmt.Errorf("expected number, got %s", typeName(v))
+	}
+	return x, nil
+}
+
+// finite returns x, or null if x is NaN or infinite.
+func finite(x float64) any {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return nil
+	}
+	return x
+}
+
+// format returns the text of v used by concat. Null values are skipped.
+func format(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(x)
+	}
+}
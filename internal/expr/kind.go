@@ -0,0 +1,92 @@
+package expr
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kind is the static type of an expression, as far as it can be known before evaluation.
+type Kind int
+
+const (
+	// KindUnknown is the kind of columns holding values of mixed or unsupported types. Operations on it are checked when evaluated.
+	KindUnknown Kind = iota
+	// KindNull is the kind of the null literal.
+	KindNull
+	KindBool
+	KindInt
+	KindFloat
+	KindString
+	KindTime
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindUnknown:
+		return "unknown"
+	case KindNull:
+		return "null"
+	case KindBool:
+		return "bool"
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "float"
+	case KindString:
+		return "string"
+	case KindTime:
+		return "time"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+func (k Kind) numeric() bool {
+	return k == KindInt || k == KindFloat
+}
+
+// dynamic reports whether values of kind k can only be checked when evaluated.
+func (k Kind) dynamic() bool {
+	return k == KindUnknown || k == KindNull
+}
+
+// kindOf returns the kind of a runtime value.
+func kindOf(v any) Kind {
+	switch v.(type) {
+	case nil:
+		return KindNull
+	case bool:
+		return KindBool
+	case int64:
+		return KindInt
+	case float64:
+		return KindFloat
+	case string:
+		return KindString
+	case time.Time:
+		return KindTime
+	default:
+		return KindUnknown
+	}
+}
+
+// normalize converts the integer types that columns may hold to int64, the only integer type used during evaluation.
+func normalize(v any) any {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case float32:
+		return float64(n)
+	default:
+		return v
+	}
+}
+
+func typeName(v any) string {
+	if k := kindOf(v); k != KindUnknown {
+		return k.String()
+	}
+	return fmt.Sprintf("%T", v)
+}
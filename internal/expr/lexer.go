@@ -0,0 +1,168 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TokenKind identifies the kind of a Token.
+type TokenKind int
+
+const (
+	TokenEOF TokenKind = iota
+	// TokenIdent is a bare identifier, which may also be a keyword such as and, null or in.
+	TokenIdent
+	// TokenQuotedIdent is an identifier enclosed in backticks. It is never a keyword.
+	TokenQuotedIdent
+	TokenNumber
+	TokenString
+	// TokenOp is an operator or punctuation, such as +, <=, ( or ,.
+	TokenOp
+)
+
+// Token is a lexical unit of an expression.
+type Token struct {
+	Kind TokenKind
+	// Text is the identifier name, the unquoted string value, the number literal or the operator.
+	Text string
+	// Pos is the byte offset of the token in the source.
+	Pos int
+}
+
+// Is reports whether the token is the given operator, or the given keyword compared case-insensitively.
+func (t Token) Is(text string) bool {
+	switch t.Kind {
+	case TokenOp:
+		return t.Text == text
+	case TokenIdent:
+		return strings.EqualFold(t.Text, text)
+	default:
+		return false
+	}
+}
+
+var twoCharOps = []string{"==", "!=", "<>", "<=", ">=", "&&", "||"}
+
+const oneCharOps = "(),+-*/%^<>=!.;"
+
+// Tokenize splits src into tokens. The last token is always TokenEOF.
+//
+// Strings are enclosed in single or double quotes; a backslash escapes the next character and a doubled quote stands for the quote itself.
+// Identifiers that are not plain words, such as column names with spaces, are enclosed in backticks.
+func Tokenize(src string) ([]Token, error) {
+	tokens := []Token{}
+	i := 0
+
+	for i < len(src) {
+		c := rune(src[i])
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '_' || unicode.IsLetter(c) || c >= 0x80:
+			start := i
+			for i < len(src) {
+				r := rune(src[i])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && r < 0x80 {
+					break
+				}
+				i++
+			}
+			tokens = append(tokens, Token{Kind: TokenIdent, Text: src[start:i], Pos: start})
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1]))):
+			start := i
+			i = scanNumber(src, i)
+			tokens = append(tokens, Token{Kind: TokenNumber, Text: src[start:i], Pos: start})
+		case c == '\'' || c == '"' || c == '`':
+			text, end, err := scanQuoted(src, i)
+			if err != nil {
+				return nil, err
+			}
+
+			kind := TokenString
+			if c == '`' {
+				kind = TokenQuotedIdent
+			}
+			tokens = append(tokens, Token{Kind: kind, Text: text, Pos: i})
+			i = end
+		default:
+			op := ""
+			for _, two := range twoCharOps {
+				if strings.HasPrefix(src[i:], two) {
+					op = two
+					break
+				}
+			}
+			if op == "" && strings.ContainsRune(oneCharOps, c) {
+				op = string(c)
+			}
+			if op == "" {
+				return nil, fmt.Errorf("expr: unexpected character %q at position %d", c, i)
+			}
+
+			tokens = append(tokens, Token{Kind: TokenOp, Text: op, Pos: i})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, Token{Kind: TokenEOF, Pos: len(src)}), nil
+}
+
+func scanNumber(src string, i int) int {
+	digits := func() {
+		for i < len(src) && src[i] >= '0' && src[i] <= '9' {
+			i++
+		}
+	}
+
+	digits()
+	if i < len(src) && src[i] == '.' {
+		i++
+		digits()
+	}
+	if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+		j := i + 1
+		if j < len(src) && (src[j] == '+' || src[j] == '-') {
+			j++
+		}
+		if j < len(src) && src[j] >= '0' && src[j] <= '9' {
+			i = j
+			digits()
+		}
+	}
+
+	return i
+}
+
+func scanQuoted(src string, start int) (string, int, error) {
+	quote := src[start]
+	var b strings.Builder
+
+	for i := start + 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\\' && quote != '`' && i+1 < len(src):
+			i++
+			switch src[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(src[i])
+			}
+		case c == quote:
+			if i+1 < len(src) && src[i+1] == quote {
+				b.WriteByte(quote)
+				i++
+				continue
+			}
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("expr: unterminated %c at position %d", quote, start)
+}
@@ -0,0 +1,454 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keywords cannot be used as bare column names; they must be enclosed in backticks.
+var keywords = map[string]bool{
+	"and": true, "or": true, "not": true, "is": true, "null": true, "true": true, "false": true,
+	"in": true, "like": true, "between": true, "distinct": true,
+}
+
+func isKeyword(s string) bool {
+	return keywords[strings.ToLower(s)]
+}
+
+// Parse parses src as a single expression.
+func Parse(src string) (Node, error) {
+	p, err := NewParser(src)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := p.ParseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.Peek(); t.Kind != TokenEOF {
+		return nil, p.unexpected(t)
+	}
+	return n, nil
+}
+
+// Parser reads expressions from a stream of tokens.
+//
+// Besides Parse, it is used by languages embedding expressions, such as SQL: ParseExpr stops at the first token that cannot continue the expression, and the caller reads on with Peek and Next.
+type Parser struct {
+	tokens []Token
+	pos    int
+}
+
+// NewParser tokenizes src and returns a Parser positioned on its first token.
+func NewParser(src string) (*Parser, error) {
+	tokens, err := Tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	return &Parser{tokens: tokens}, nil
+}
+
+// Peek returns the current token without consuming it.
+func (p *Parser) Peek() Token {
+	return p.tokens[p.pos]
+}
+
+// PeekAt returns the token n positions after the current one, or the final TokenEOF.
+func (p *Parser) PeekAt(n int) Token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+// Next consumes and returns the current token.
+func (p *Parser) Next() Token {
+	t := p.tokens[p.pos]
+	if t.Kind != TokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// Accept consumes the current token if it is the given operator or keyword, and reports whether it did.
+func (p *Parser) Accept(text string) bool {
+	if p.Peek().Is(text) {
+		p.Next()
+		return true
+	}
+	return false
+}
+
+// Expect consumes the current token if it is the given operator or keyword, and returns an error otherwise.
+func (p *Parser) Expect(text string) error {
+	if !p.Accept(text) {
+		t := p.Peek()
+		return fmt.Errorf("expr: expected %s at position %d, found %s", text, t.Pos, describe(t))
+	}
+	return nil
+}
+
+// Errorf returns an error located at the current token.
+func (p *Parser) Errorf(format string, args ...any) error {
+	return fmt.Errorf("expr: %s at position %d", fmt.Sprintf(format, args...), p.Peek().Pos)
+}
+
+func (p *Parser) unexpected(t Token) error {
+	return fmt.Errorf("expr: unexpected %s at position %d", describe(t), t.Pos)
+}
+
+func describe(t Token) string {
+	switch t.Kind {
+	case TokenEOF:
+		return "end of expression"
+	case TokenString:
+		return fmt.Sprintf("string %q", t.Text)
+	default:
+		return fmt.Sprintf("%q", t.Text)
+	}
+}
+
+// ParseExpr parses an expression starting at the current token.
+func (p *Parser) ParseExpr() (Node, error) {
+	return p.parseOr()
+}
+
+func (p *Parser) parseOr() (Node, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.Peek()
+		if !t.Is("||") && !t.Is("or") {
+			return x, nil
+		}
+		p.Next()
+
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{Op: "||", X: x, Y: y, Position: t.Pos}
+	}
+}
+
+func (p *Parser) parseAnd() (Node, error) {
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.Peek()
+		if !t.Is("&&") && !t.Is("and") {
+			return x, nil
+		}
+		p.Next()
+
+		y, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{Op: "&&", X: x, Y: y, Position: t.Pos}
+	}
+}
+
+func (p *Parser) parseNot() (Node, error) {
+	t := p.Peek()
+	if t.Is("!") || t.Is("not") {
+		p.Next()
+
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: "!", X: x, Position: t.Pos}, nil
+	}
+
+	return p.parseComparison()
+}
+
+var comparisonOps = map[string]string{
+	"==": "==", "=": "==", "!=": "!=", "<>": "!=", "<": "<", "<=": "<=", ">": ">", ">=": ">=",
+}
+
+func (p *Parser) parseComparison() (Node, error) {
+	x, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.Peek()
+	if t.Kind == TokenOp {
+		if op, ok := comparisonOps[t.Text]; ok {
+			p.Next()
+
+			y, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return &Binary{Op: op, X: x, Y: y, Position: t.Pos}, nil
+		}
+	}
+
+	if t.Is("is") {
+		p.Next()
+		negated := p.Accept("not")
+		if err := p.Expect("null"); err != nil {
+			return nil, err
+		}
+		return &IsNull{X: x, Not: negated, Position: t.Pos}, nil
+	}
+
+	negated := false
+	if t.Is("not") && (p.PeekAt(1).Is("in") || p.PeekAt(1).Is("like") || p.PeekAt(1).Is("between")) {
+		p.Next()
+		negated = true
+		t = p.Peek()
+	}
+
+	switch {
+	case t.Is("in"):
+		p.Next()
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &In{X: x, List: list, Not: negated, Position: t.Pos}, nil
+	case t.Is("like"):
+		p.Next()
+		pattern, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &Like{X: x, Pattern: pattern, Not: negated, Position: t.Pos}, nil
+	case t.Is("between"):
+		p.Next()
+		lo, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.Expect("and"); err != nil {
+			return nil, err
+		}
+		hi, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &Between{X: x, Lo: lo, Hi: hi, Not: negated, Position: t.Pos}, nil
+	}
+
+	return x, nil
+}
+
+func (p *Parser) parseList() ([]Node, error) {
+	if err := p.Expect("("); err != nil {
+		return nil, err
+	}
+
+	list := []Node{}
+	for {
+		item, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+
+		if !p.Accept(",") {
+			break
+		}
+	}
+
+	if err := p.Expect(")"); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (p *Parser) parseAdditive() (Node, error) {
+	x, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.Peek()
+		if !t.Is("+") && !t.Is("-") {
+			return x, nil
+		}
+		p.Next()
+
+		y, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{Op: t.Text, X: x, Y: y, Position: t.Pos}
+	}
+}
+
+func (p *Parser) parseMultiplicative() (Node, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.Peek()
+		if !t.Is("*") && !t.Is("/") && !t.Is("%") {
+			return x, nil
+		}
+		p.Next()
+
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{Op: t.Text, X: x, Y: y, Position: t.Pos}
+	}
+}
+
+func (p *Parser) parseUnary() (Node, error) {
+	t := p.Peek()
+	if t.Is("-") || t.Is("+") {
+		p.Next()
+
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if t.Text == "+" {
+			return x, nil
+		}
+		return &Unary{Op: "-", X: x, Position: t.Pos}, nil
+	}
+
+	return p.parsePower()
+}
+
+// parsePower parses the right-associative ^ operator, which binds tighter than unary minus: -2^2 is -(2^2).
+func (p *Parser) parsePower() (Node, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.Peek()
+	if !t.Is("^") {
+		return x, nil
+	}
+	p.Next()
+
+	y, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &Binary{Op: "^", X: x, Y: y, Position: t.Pos}, nil
+}
+
+func (p *Parser) parsePrimary() (Node, error) {
+	t := p.Next()
+
+	switch t.Kind {
+	case TokenNumber:
+		return parseNumber(t)
+	case TokenString:
+		return &Literal{Value: t.Text, Position: t.Pos}, nil
+	case TokenQuotedIdent:
+		return p.parseIdent(t)
+	case TokenIdent:
+		switch strings.ToLower(t.Text) {
+		case "null":
+			return &Literal{Value: nil, Position: t.Pos}, nil
+		case "true":
+			return &Literal{Value: true, Position: t.Pos}, nil
+		case "false":
+			return &Literal{Value: false, Position: t.Pos}, nil
+		}
+
+		if isKeyword(t.Text) {
+			return nil, p.unexpected(t)
+		}
+		if p.Peek().Is("(") {
+			return p.parseCall(t)
+		}
+		return p.parseIdent(t)
+	case TokenOp:
+		if t.Text == "(" {
+			x, err := p.ParseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.Expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	}
+
+	return nil, p.unexpected(t)
+}
+
+func (p *Parser) parseIdent(t Token) (Node, error) {
+	if !p.Peek().Is(".") {
+		return &Ident{Name: t.Text, Position: t.Pos}, nil
+	}
+	p.Next()
+
+	name := p.Next()
+	if name.Kind != TokenIdent && name.Kind != TokenQuotedIdent {
+		return nil, p.unexpected(name)
+	}
+	return &Ident{Qualifier: t.Text, Name: name.Text, Position: t.Pos}, nil
+}
+
+func (p *Parser) parseCall(t Token) (Node, error) {
+	p.Next()
+	call := &Call{Name: strings.ToLower(t.Text), Args: []Node{}, Position: t.Pos}
+
+	if p.Accept(")") {
+		return call, nil
+	}
+
+	call.Distinct = p.Accept("distinct")
+
+	for {
+		if s := p.Peek(); s.Is("*") {
+			p.Next()
+			call.Args = append(call.Args, &Star{Position: s.Pos})
+		} else {
+			arg, err := p.ParseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, arg)
+		}
+
+		if !p.Accept(",") {
+			break
+		}
+	}
+
+	if err := p.Expect(")"); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+func parseNumber(t Token) (Node, error) {
+	if !strings.ContainsAny(t.Text, ".eE") {
+		if n, err := strconv.ParseInt(t.Text, 10, 64); err == nil {
+			return &Literal{Value: n, Position: t.Pos}, nil
+		}
+	}
+
+	x, err := strconv.ParseFloat(t.Text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("expr: invalid number %s at position %d", t.Text, t.Pos)
+	}
+	return &Literal{Value: x, Position: t.Pos}, nil
+}
@@ -0,0 +1,22 @@
+package jsonio
+
+type options struct {
+	flatten bool
+}
+
+type Option func(*options)
+
+// WithFlatten reads nested objects as separate columns, named by joining the keys with dots.
+func WithFlatten() Option {
+	return func(o *options) {
+		o.flatten = true
+	}
+}
+
+func newOptions(argOpts []Option) options {
+	var opts options
+	for _, opt := range argOpts {
+		opt(&opts)
+	}
+	return opts
+}
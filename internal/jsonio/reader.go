@@ -0,0 +1,372 @@
+// Package jsonio reads JSON documents and JSON Lines streams into column-oriented data.
+package jsonio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Read reads a JSON file, see ReadFrom.
+func Read(path string, argOpts ...Option) (map[string][]any, []string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	return ReadFrom(f, argOpts...)
+}
+
+// ReadFrom reads a JSON document from r, either an array of records or an object mapping column names to arrays of values.
+//
+// Columns of records are ordered by first appearance, and keys missing from a record are read as nil.
+func ReadFrom(r io.Reader, argOpts ...Option) (map[string][]any, []string, error) {
+	b := newBuilder(newOptions(argOpts))
+	dec := newDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, fmt.Errorf("json: %w", err)
+	}
+
+	switch tok {
+	case json.Delim('['):
+		for dec.More() {
+			if err := b.readRecord(dec); err != nil {
+				return nil, nil, fmt.Errorf("json: record %d: %w", b.rows+1, unexpectedEOF(err))
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, nil, fmt.Errorf("json: %w", unexpectedEOF(err))
+		}
+	case json.Delim('{'):
+		if err := b.readColumns(dec, ""); err != nil {
+			return nil, nil, fmt.Errorf("json: %w", unexpectedEOF(err))
+		}
+	default:
+		return nil, nil, fmt.Errorf("json: expected an array of records or an object of columns")
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, nil, fmt.Errorf("json: unexpected data after the top-level value")
+	}
+
+	data, columns := b.finish()
+	return data, columns, nil
+}
+
+// ReadLines reads a JSON Lines file, see ReadLinesFrom.
+func ReadLines(path string, argOpts ...Option) (map[string][]any, []string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	return ReadLinesFrom(f, argOpts...)
+}
+
+// ReadLinesFrom reads one record per line from r, as they are decoded. Blank lines are skipped.
+//
+// The columns are the union of the keys of all records, ordered by first appearance, and keys missing from a record are read as nil.
+func ReadLinesFrom(r io.Reader, argOpts ...Option) (map[string][]any, []string, error) {
+	b := newBuilder(newOptions(argOpts))
+	dec := newDecoder(r)
+
+	for {
+		if err := b.readRecord(dec); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, nil, fmt.Errorf("jsonl: record %d: %w", b.rows+1, err)
+		}
+	}
+
+	data, columns := b.finish()
+	return data, columns, nil
+}
+
+func newDecoder(r io.Reader) *json.Decoder {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return dec
+}
+
+// builder collects values into columns.
+type builder struct {
+	opts    options
+	columns []string
+	index   map[string]int
+	data    [][]any
+	rows    int
+}
+
+func newBuilder(opts options) *builder {
+	return &builder{opts: opts, index: map[string]int{}}
+}
+
+// column returns the index of the named column, adding it with nil values for the rows read so far.
+func (b *builder) column(name string) int {
+	j, ok := b.index[name]
+	if !ok {
+		j = len(b.columns)
+		b.index[name] = j
+		b.columns = append(b.columns, name)
+		b.data = append(b.data, make([]any, b.rows, b.rows+1))
+	}
+	return j
+}
+
+// set sets the value of a column in the current row. A key repeated in a record overrides the previous value.
+func (b *builder) set(name string, v any) {
+	j := b.column(name)
+	if len(b.data[j]) > b.rows {
+		b.data[j][b.rows] = v
+		return
+	}
+	b.data[j] = append(b.data[j], v)
+}
+
+// readRecord reads a JSON object as a row. It returns io.EOF when there are no more values.
+func (b *builder) readRecord(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected an object, got %v", tok)
+	}
+
+	if err := b.readFields(dec, ""); err != nil {
+		return unexpectedEOF(err)
+	}
+
+	b.rows++
+	for j := range b.data {
+		if len(b.data[j]) < b.rows {
+			b.data[j] = append(b.data[j], nil)
+		}
+	}
+	return nil
+}
+
+// readFields reads the fields of an object, after its opening brace and up to its closing brace, into the current row.
+func (b *builder) readFields(dec *json.Decoder, prefix string) error {
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return err
+		}
+
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if tok == json.Delim('{') && b.opts.flatten {
+			if err := b.readFields(dec, prefix+key+"."); err != nil {
+				return err
+			}
+			continue
+		}
+
+		v, err := readValue(dec, tok)
+		if err != nil {
+			return err
+		}
+		b.set(prefix+key, v)
+	}
+
+	_, err := dec.Token()
+	return err
+}
+
+// readColumns reads the fields of an object of columns, after its opening brace, up to its closing brace.
+func (b *builder) readColumns(dec *json.Decoder, prefix string) error {
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return err
+		}
+		name := prefix + key
+
+		tok, err := dec.Token()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+
+		if tok == json.Delim('{') && b.opts.flatten {
+			if err := b.readColumns(dec, name+"."); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if tok != json.Delim('[') {
+			return fmt.Errorf("column %s: expected an array of values", name)
+		}
+		if _, exists := b.index[name]; exists {
+			return fmt.Errorf("column %s: duplicate column", name)
+		}
+
+		j := b.column(name)
+		for dec.More() {
+			v, err := decodeValue(dec)
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			b.data[j] = append(b.data[j], v)
+		}
+		if _, err := dec.Token(); err != nil {
+			return unexpectedEOF(err)
+		}
+	}
+
+	_, err := dec.Token()
+	return err
+}
+
+func readKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	return tok.(string), nil
+}
+
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	return readValue(dec, tok)
+}
+
+// readValue converts the value starting with tok. Numbers are read as int64 when they are integers, and as float64 otherwise.
+// Nested objects and arrays are read as map[string]any and []any.
+func readValue(dec *json.Decoder, tok json.Token) (any, error) {
+	switch tok := tok.(type) {
+	case json.Number:
+		return number(tok), nil
+	case json.Delim:
+		switch tok {
+		case '{':
+			obj := map[string]any{}
+			for dec.More() {
+				key, err := readKey(dec)
+				if err != nil {
+					return nil, err
+				}
+				if obj[key], err = decodeValue(dec); err != nil {
+					return nil, err
+				}
+			}
+			_, err := dec.Token()
+			return obj, err
+
+		case '[':
+			arr := []any{}
+			for dec.More() {
+				v, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, v)
+			}
+			_, err := dec.Token()
+			return arr, err
+		}
+		return nil, fmt.Errorf("unexpected %v", tok)
+	default:
+		return tok, nil
+	}
+}
+
+func number(n json.Number) any {
+	s := string(n)
+	if !strings.ContainsAny(s, ".eE") {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return s
+	}
+	return f
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// finish returns the columns, converting their values to consistent types:
+// integer columns that also hold floating point values are promoted to float64, and string columns holding only RFC 3339 times are read as time.Time.
+func (b *builder) finish() (map[string][]any, []string) {
+	data := make(map[string][]any, len(b.columns))
+	for j, c := range b.columns {
+		values := b.data[j]
+		promoteInts(values)
+		parseTimes(values)
+		data[c] = values
+	}
+	return data, b.columns
+}
+
+func promoteInts(values []any) {
+	hasFloat := false
+	for _, v := range values {
+		switch v.(type) {
+		case nil, int64:
+		case float64:
+			hasFloat = true
+		default:
+			return
+		}
+	}
+	if !hasFloat {
+		return
+	}
+
+	for i, v := range values {
+		if n, ok := v.(int64); ok {
+			values[i] = float64(n)
+		}
+	}
+}
+
+func parseTimes(values []any) {
+	times := make([]any, len(values))
+	found := false
+
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+
+		s, ok := v.(string)
+		if !ok {
+			return
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return
+		}
+		times[i] = t
+		found = true
+	}
+
+	if found {
+		copy(values, times)
+	}
+}
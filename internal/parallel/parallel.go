@@ -0,0 +1,145 @@
+// Package parallel runs independent units of work, such as the columns of a table, on a bounded number of goroutines.
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var workers atomic.Int64
+
+// SetWorkers sets the number of goroutines used by For and Chunks, including the calling goroutine. A value of zero or less restores the default, runtime.GOMAXPROCS(0).
+func SetWorkers(n int) {
+	if n < 0 {
+		n = 0
+	}
+	workers.Store(int64(n))
+}
+
+// Workers returns the number of goroutines used by For and Chunks.
+func Workers() int {
+	if n := workers.Load(); n > 0 {
+		return int(n)
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// busy is the number of goroutines started by For that are still running, across all calls.
+var busy atomic.Int64
+
+// reserve reserves up to n goroutines from the Workers budget, less the goroutines already started by other calls, and returns how many were reserved.
+// The calling goroutine is not counted, since it takes part in the work.
+func reserve(n int) int {
+	for {
+		current := busy.Load()
+		k := min(int64(n), int64(Workers()-1)-current)
+		if k <= 0 {
+			return 0
+		}
+		if busy.CompareAndSwap(current, current+k) {
+			return int(k)
+		}
+	}
+}
+
+// For calls f for every index in [0, n), using up to Workers goroutines, including the calling one.
+//
+// Goroutines are taken from a budget of Workers shared by all calls, so a For or Chunks called from f, for example to transform the values of a column
+// while columns are processed in parallel, runs serially once every worker is busy rather than multiplying the number of goroutines.
+//
+// Results are deterministic as long as f(i) only writes to slots owned by index i: if several calls fail, the error of the lowest index is returned,
+// which is the error a serial loop would have stopped at. Indexes above a failed one are skipped.
+// For stops starting new calls once ctx is done, and then returns ctx.Err() unless a call failed at a lower index.
+func For(ctx context.Context, n int, f func(i int) error) error {
+	extra := 0
+	if n > 1 {
+		extra = reserve(n - 1)
+	}
+
+	if extra == 0 {
+		for i := 0; i < n; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := f(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	defer busy.Add(-int64(extra))
+
+	var (
+		next   atomic.Int64
+		failed atomic.Int64
+		wg     sync.WaitGroup
+	)
+	errs := make([]error, n)
+	failed.Store(int64(n))
+
+	work := func() {
+		for {
+			i := int(next.Add(1) - 1)
+			if i >= n || int64(i) > failed.Load() {
+				return
+			}
+
+			err := ctx.Err()
+			if err == nil {
+				err = f(i)
+			}
+			if err != nil {
+				errs[i] = err
+				for {
+					current := failed.Load()
+					if int64(i) >= current || failed.CompareAndSwap(current, int64(i)) {
+						break
+					}
+				}
+			}
+		}
+	}
+
+	for range extra {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			work()
+		}()
+	}
+	work()
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Chunks splits [0, n) into consecutive ranges of at least minChunk indexes, and calls f for every range using up to Workers goroutines.
+//
+// Ranges are disjoint, so f may write the results for its range into a shared slice. Chunks returns ctx.Err() if ctx is done before every range has been processed.
+func Chunks(ctx context.Context, n, minChunk int, f func(from, to int)) error {
+	if minChunk < 1 {
+		minChunk = 1
+	}
+
+	count := min(Workers(), (n+minChunk-1)/minChunk)
+	if count <= 1 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		f(0, n)
+		return nil
+	}
+
+	size := (n + count - 1) / count
+	return For(ctx, count, func(i int) error {
+		f(i*size, min((i+1)*size, n))
+		return nil
+	})
+}
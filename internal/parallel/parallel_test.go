@@ -0,0 +1,148 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func withWorkers(tb testing.TB, n int) {
+	tb.Helper()
+
+	previous := workers.Load()
+	SetWorkers(n)
+	tb.Cleanup(func() { workers.Store(previous) })
+}
+
+func TestFor(t *testing.T) {
+	for _, w := range []int{1, 2, 8} {
+		t.Run(fmt.Sprint(w), func(t *testing.T) {
+			withWorkers(t, w)
+
+			results := make([]int, 100)
+			err := For(context.Background(), len(results), func(i int) error {
+				results[i] = i * i
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, r := range results {
+				if r != i*i {
+					t.Fatalf("results[%d] = %d, want %d", i, r, i*i)
+				}
+			}
+		})
+	}
+}
+
+func TestForReturnsLowestError(t *testing.T) {
+	for _, w := range []int{1, 4} {
+		t.Run(fmt.Sprint(w), func(t *testing.T) {
+			withWorkers(t, w)
+
+			err := For(context.Background(), 50, func(i int) error {
+				if i%10 == 7 {
+					return fmt.Errorf("index %d", i)
+				}
+				return nil
+			})
+			if err == nil || err.Error() != "index 7" {
+				t.Fatalf("err = %v, want index 7", err)
+			}
+		})
+	}
+}
+
+func TestForStopsWhenContextIsDone(t *testing.T) {
+	withWorkers(t, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls atomic.Int64
+	err := For(ctx, 100, func(i int) error {
+		calls.Add(1)
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("f was called %d times", calls.Load())
+	}
+}
+
+func TestNestedCallsShareWorkers(t *testing.T) {
+	const w = 4
+	withWorkers(t, w)
+
+	var running, peak atomic.Int64
+	err := For(context.Background(), w, func(int) error {
+		return Chunks(context.Background(), w*100, 100, func(from, to int) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := peak.Load(); p > w {
+		t.Errorf("%d calls ran at the same time, want at most %d", p, w)
+	}
+	if b := busy.Load(); b != 0 {
+		t.Errorf("%d goroutines still reserved", b)
+	}
+}
+
+func TestChunks(t *testing.T) {
+	withWorkers(t, 3)
+
+	tests := []struct {
+		n, minChunk int
+		ranges      int
+	}{
+		{n: 0, minChunk: 10, ranges: 1},
+		{n: 5, minChunk: 10, ranges: 1},
+		{n: 25, minChunk: 10, ranges: 3},
+		{n: 1000, minChunk: 10, ranges: 3},
+		{n: 7, minChunk: 0, ranges: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n, "/", tt.minChunk), func(t *testing.T) {
+			covered := make([]int, tt.n)
+			var ranges atomic.Int64
+
+			err := Chunks(context.Background(), tt.n, tt.minChunk, func(from, to int) {
+				ranges.Add(1)
+				for i := from; i < to; i++ {
+					covered[i]++
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := ranges.Load(); got != int64(tt.ranges) {
+				t.Errorf("ranges = %d, want %d", got, tt.ranges)
+			}
+			for i, c := range covered {
+				if c != 1 {
+					t.Fatalf("index %d covered %d times", i, c)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-rowan/rowan/table"
+)
+
+// Builder accumulates rows one at a time into column-oriented data, inferring the type of every cell as it arrives.
+//
+// Readers that stream their records use a Builder directly so that a source never has to be held in memory as [][]string in addition to the parsed columns.
+// A Builder can also be flushed repeatedly to produce consecutive chunks of the same source.
+type Builder struct {
+	columns []string
+	keep    []int
+	opts    Options
+	fields  []*Field
+	times   timeParser
+	data    [][]any
+	rows    int
+	floats  []bool
+	raw     [][]string
+	fixed   []bool
+}
+
+// NewBuilder creates a Builder for the given columns.
+//
+// An error is returned if the schema of opts refers to a column that is not part of columns.
+func NewBuilder(columns []string, opts ...Options) (*Builder, error) {
+	b := &Builder{
+		columns: columns,
+		data:    make([][]any, len(columns)),
+		floats:  make([]bool, len(columns)),
+	}
+	if len(opts) > 0 {
+		b.opts = opts[0]
+	}
+
+	if err := b.opts.Schema.Check(columns); err != nil {
+		return nil, err
+	}
+	b.fields = b.opts.Schema.fields(columns)
+	b.times = newTimeParser(b.opts)
+
+	if b.opts.FixTypes {
+		if b.fields == nil {
+			b.fields = make([]*Field, len(columns))
+		}
+		b.raw = make([][]string, len(columns))
+		b.fixed = make([]bool, len(columns))
+	}
+
+	keep, err := b.opts.keep(columns)
+	if err != nil {
+		return nil, err
+	}
+	b.keep = keep
+
+	return b, nil
+}
+
+// keep returns the indexes of the columns selected by o.Columns, in the order of columns.
+func (o Options) keep(columns []string) ([]int, error) {
+	if o.Columns == nil {
+		keep := make([]int, len(columns))
+		for j := range keep {
+			keep[j] = j
+		}
+		return keep, nil
+	}
+
+	selected := make(map[string]bool, len(o.Columns))
+	for _, c := range o.Columns {
+		selected[c] = true
+	}
+
+	keep := []int{}
+	for j, c := range columns {
+		if selected[c] {
+			keep = append(keep, j)
+			delete(selected, c)
+		}
+	}
+
+	for _, c := range o.Columns {
+		if selected[c] {
+			return nil, fmt.Errorf("csv: column %s does not exist", c)
+		}
+	}
+
+	return keep, nil
+}
+
+// Columns returns the names of the columns held by the Builder.
+func (b *Builder) Columns() []string {
+	columns := make([]string, len(b.keep))
+	for i, j := range b.keep {
+		columns[i] = b.columns[j]
+	}
+	return columns
+}
+
+// Append parses a single row and appends its cells to the column data.
+//
+// Rows with an unexpected number of cells are handled according to the configured RaggedPolicy.
+// Cells of columns described by the schema are converted to the declared type, and conversion failures are reported with the row number and column name.
+func (b *Builder) Append(row []string) error {
+	b.rows++
+
+	columnsCount := len(b.columns)
+	rowsCount := len(row)
+	if rowsCount != columnsCount {
+		if b.opts.Ragged == RaggedDrop {
+			return nil
+		}
+
+		if b.opts.Ragged != RaggedPad || rowsCount > columnsCount {
+			return fmt.Errorf("csv: row %d has %d columns, expected %d", b.rows, rowsCount, columnsCount)
+		}
+	}
+
+	for _, j := range b.keep {
+		var (
+			v   any
+			err error
+		)
+
+		if j >= rowsCount {
+			v, err = b.null(j)
+		} else {
+			v, err = b.parseCell(j, row[j])
+		}
+		if err != nil {
+			return err
+		}
+
+		b.data[j] = append(b.data[j], v)
+		if b.raw != nil && b.fields[j] == nil {
+			var cell string
+			if j < rowsCount {
+				cell = strings.TrimSpace(row[j])
+			}
+			b.raw[j] = append(b.raw[j], cell)
+		}
+	}
+
+	return nil
+}
+
+func (b *Builder) parseCell(j int, cell string) (any, error) {
+	if s := strings.TrimSpace(cell); s == "" || b.opts.isNull(s) {
+		return b.null(j)
+	}
+
+	if f := b.field(j); f != nil {
+		v, err := f.parseCell(b.rows, cell, b.times)
+		if err != nil && b.fixed != nil && b.fixed[j] {
+			return nil, fmt.Errorf("%w, the type of the first chunk holding values of the column", err)
+		}
+		return v, err
+	}
+
+	return inferType(cell, b.times), nil
+}
+
+func (b *Builder) null(j int) (any, error) {
+	if f := b.field(j); f != nil {
+		return f.null(b.rows)
+	}
+	return nil, nil
+}
+
+func (b *Builder) field(j int) *Field {
+	if b.fields == nil {
+		return nil
+	}
+	return b.fields[j]
+}
+
+// Buffered returns the number of rows appended since the last Flush.
+func (b *Builder) Buffered() int {
+	if len(b.keep) == 0 {
+		return 0
+	}
+	return len(b.data[b.keep[0]])
+}
+
+// Flush returns the rows accumulated since the previous Flush keyed by column name, and resets the buffer.
+//
+// Integer columns that also contain floating point values are promoted to float64 so every column holds a consistent numeric type.
+// Promotion is remembered across flushes: once a column has been promoted, later chunks of that column are promoted as well, even if they only contain integers.
+// Promotion only carries forward, so chunks flushed before the first float64 value of a column hold integers, and a column whose later cells are not numbers
+// holds mixed values in the chunks containing them only. Columns described by the schema are converted to the same type in every chunk.
+//
+// With Options.FixTypes, the type of a column outside the schema is fixed by the first chunk holding values of the column instead:
+// integers mixed with floating point values are promoted to float64, and columns mixing other types hold the text of their cells.
+// The cells of later chunks are converted to that type like the cells of a column described by the schema, and cells that can not be converted are reported as errors.
+func (b *Builder) Flush() map[string][]any {
+	data := make(map[string][]any, len(b.keep))
+
+	for _, j := range b.keep {
+		c := b.columns[j]
+		values := b.data[j]
+		if values == nil {
+			values = []any{}
+		}
+
+		if b.opts.FixTypes && b.fields[j] == nil {
+			b.fix(j, values)
+		}
+		if !b.floats[j] {
+			b.floats[j] = hasFloat(values)
+		}
+		if b.floats[j] {
+			promoteInts(values)
+		}
+
+		data[c] = values
+		b.data[j] = nil
+	}
+
+	return data
+}
+
+// fix fixes the type of column j to the type of values, unless they are all missing, and converts values to it.
+func (b *Builder) fix(j int, values []any) {
+	raw := b.raw[j]
+	b.raw[j] = nil
+
+	var typ columnType
+	for _, v := range values {
+		typ.add(v)
+	}
+	if !typ.seen {
+		return
+	}
+
+	if typ.typ == table.TypeString {
+		for i, v := range values {
+			if v != nil {
+				values[i] = raw[i]
+			}
+		}
+	}
+
+	b.fields[j] = &Field{Name: b.columns[j], Type: typ.typ, Nullable: true}
+	b.fixed[j] = true
+}
+
+func hasFloat(values []any) bool {
+	for _, v := range values {
+		if _, ok := v.(float64); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func promoteInts(values []any) {
+	for i, v := range values {
+		if n, ok := v.(int64); ok {
+			values[i] = float64(n)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package parser
+
+// RaggedPolicy controls how a Builder handles rows whose number of cells differs from the number of columns.
+type RaggedPolicy int
+
+const (
+	// RaggedError rejects ragged rows with an error. This is the default.
+	RaggedError RaggedPolicy = iota
+	// RaggedPad fills missing trailing cells of short rows with nil. Rows with too many cells are still rejected.
+	RaggedPad
+	// RaggedDrop silently skips every ragged row.
+	RaggedDrop
+)
+
+// Options configures how a Builder turns raw cells into values.
+type Options struct {
+	// NullValues lists cell contents, compared after trimming surrounding spaces, that are parsed as nil in addition to empty cells.
+	NullValues []string
+	// Ragged is the policy applied to rows with an unexpected number of cells.
+	Ragged RaggedPolicy
+	// Schema declares the types of some or all columns. Columns outside the schema are inferred.
+	Schema Schema
+	// TimeLayouts lists additional layouts, as accepted by time.Parse, tried before the default ones when parsing time values.
+	TimeLayouts []string
+	// ExcelSerialDates reads numbers in columns declared as time as Excel serial dates.
+	ExcelSerialDates bool
+	// Columns restricts the data to the named columns, which keep the order of the source. The cells of other columns are not parsed. All columns are kept when Columns is nil.
+	Columns []string
+	// FixTypes fixes the type of every column outside the schema to the type of the first chunk flushed with values of the column, see Builder.Flush.
+	FixTypes bool
+}
+
+func (o Options) isNull(s string) bool {
+	for _, n := range o.NullValues {
+		if s == n {
+			return true
+		}
+	}
+	return false
+}
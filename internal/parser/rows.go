@@ -1,41 +1,16 @@
 package parser
 
-import "fmt"
-
-func ParseRows(columns []string, rows [][]string) (map[string][]any, error) {
-	columnsCount := len(columns)
-
-	data := make(map[string][]any, columnsCount)
-
-	for i, row := range rows {
-		rowsCount := len(row)
-		if rowsCount != columnsCount {
-			return nil, fmt.Errorf("csv: row %d has %d columns, expected %d", i+1, rowsCount, columnsCount)
-		}
-
-		for j, cell := range row {
-			col := columns[j]
-			data[col] = append(data[col], inferType(cell))
-		}
+func ParseRows(columns []string, rows [][]string, opts ...Options) (map[string][]any, error) {
+	b, err := NewBuilder(columns, opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, c := range columns {
-		hasFloat := false
-		for _, v := range data[c] {
-			if _, ok := v.(float64); ok {
-				hasFloat = true
-				break
-			}
-		}
-
-		if hasFloat {
-			for i, v := range data[c] {
-				if n, ok := v.(int64); ok {
-					data[c][i] = float64(n)
-				}
-			}
+	for _, row := range rows {
+		if err := b.Append(row); err != nil {
+			return nil, err
 		}
 	}
 
-	return data, nil
+	return b.Flush(), nil
 }
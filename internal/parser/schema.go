@@ -0,0 +1,205 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rowan/rowan/table"
+)
+
+// Field describes the expected type of a single column.
+type Field struct {
+	// Name is the name of the column the field applies to.
+	Name string
+	// Type is the type every value of the column is converted to.
+	Type table.Type
+	// Nullable reports whether the column may contain missing values. Missing values in a column that is not nullable are reported as errors.
+	Nullable bool
+	// Format is the layout used to parse TypeTime values, as accepted by time.Parse.
+	// When empty, the configured and default time layouts are tried, and Excel serial dates are accepted by the Excel reader.
+	Format string
+}
+
+// Schema lists the expected types of the columns of a source.
+//
+// Columns that are not part of the schema keep their inferred types.
+type Schema []Field
+
+// SchemaFromTypes builds a Schema of nullable fields from a map of column names to types.
+func SchemaFromTypes(types map[string]table.Type) Schema {
+	schema := make(Schema, 0, len(types))
+	for name, typ := range types {
+		schema = append(schema, Field{Name: name, Type: typ, Nullable: true})
+	}
+	return schema
+}
+
+// Field returns the field describing the given column.
+func (s Schema) Field(name string) (Field, bool) {
+	for _, f := range s {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Check reports an error if a field of the schema refers to a column that is not part of columns.
+func (s Schema) Check(columns []string) error {
+	for _, f := range s {
+		found := false
+		for _, c := range columns {
+			if c == f.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("schema: column %s not found", f.Name)
+		}
+	}
+	return nil
+}
+
+// fields returns the field of every column, or nil for columns that are not part of the schema.
+func (s Schema) fields(columns []string) []*Field {
+	if len(s) == 0 {
+		return nil
+	}
+
+	fields := make([]*Field, len(columns))
+	for j, c := range columns {
+		for i := range s {
+			if s[i].Name == c {
+				fields[j] = &s[i]
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// parseCell converts a raw cell into the type of the field.
+// Empty cells of non-string fields are treated as missing.
+func (f *Field) parseCell(row int, cell string, tp timeParser) (any, error) {
+	s := strings.TrimSpace(cell)
+	if s == "" && f.Type != table.TypeString {
+		return f.null(row)
+	}
+
+	var (
+		v   any
+		err error
+	)
+
+	switch f.Type {
+	case table.TypeString:
+		return s, nil
+	case table.TypeInt:
+		v, err = strconv.ParseInt(s, 10, 64)
+	case table.TypeFloat:
+		v, err = strconv.ParseFloat(s, 64)
+	case table.TypeBool:
+		v, err = strconv.ParseBool(s)
+	case table.TypeTime:
+		v, err = f.parseTime(s, tp)
+	default:
+		return inferType(s, tp), nil
+	}
+
+	if err != nil {
+		return nil, f.convertError(row, cell)
+	}
+
+	return v, nil
+}
+
+// null returns nil if the field accepts missing values, or an error otherwise.
+func (f *Field) null(row int) (any, error) {
+	if !f.Nullable {
+		return nil, fmt.Errorf("schema: row %d column %s: missing value in non-nullable column", row, f.Name)
+	}
+	return nil, nil
+}
+
+func (f *Field) parseTime(s string, tp timeParser) (time.Time, error) {
+	if f.Format != "" {
+		return time.Parse(f.Format, s)
+	}
+
+	t, ok := tp.parse(s)
+	if !ok {
+		return time.Time{}, fmt.Errorf("unknown time layout")
+	}
+	return t, nil
+}
+
+func (f *Field) convertError(row int, value any) error {
+	return fmt.Errorf("schema: row %d column %s: cannot convert %#v to %s", row, f.Name, value, f.Type)
+}
+
+// ConvertValue converts a Go value into the type of the field.
+//
+// The row number is only used for error reporting. Nil values and nil pointers are treated as missing.
+func (f *Field) ConvertValue(row int, value any) (any, error) {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return f.null(row)
+		}
+		rv = rv.Elem()
+	}
+
+	if !rv.IsValid() {
+		return f.null(row)
+	}
+
+	if s, ok := rv.Interface().(string); ok {
+		return f.parseCell(row, s, newTimeParser(Options{}))
+	}
+
+	switch f.Type {
+	case table.TypeString:
+		return fmt.Sprint(rv.Interface()), nil
+
+	case table.TypeInt:
+		switch {
+		case rv.CanInt():
+			return rv.Int(), nil
+		case rv.CanUint() && rv.Uint() <= math.MaxInt64:
+			return int64(rv.Uint()), nil
+		case rv.CanFloat() && rv.Float() == math.Trunc(rv.Float()):
+			return int64(rv.Float()), nil
+		}
+
+	case table.TypeFloat:
+		switch {
+		case rv.CanInt():
+			return float64(rv.Int()), nil
+		case rv.CanUint():
+			return float64(rv.Uint()), nil
+		case rv.CanFloat():
+			return rv.Float(), nil
+		}
+
+	case table.TypeBool:
+		if rv.Kind() == reflect.Bool {
+			return rv.Bool(), nil
+		}
+
+	case table.TypeTime:
+		if t, ok := rv.Interface().(time.Time); ok {
+			return t, nil
+		}
+
+	default:
+		return rv.Interface(), nil
+	}
+
+	return nil, f.convertError(row, rv.Interface())
+}
@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+)
+
+// Source is a tabular data source that yields its header followed by one record at a time.
+//
+// Next returns io.EOF once all records have been read.
+type Source interface {
+	Header() ([]string, error)
+	Next() ([]string, error)
+}
+
+// ReadAll streams every record of src into a Builder and returns the parsed column data together with the column order.
+func ReadAll(src Source, opts ...Options) (map[string][]any, []string, error) {
+	columns, err := src.Header()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := NewBuilder(columns, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for {
+		record, err := src.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := b.Append(record); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return b.Flush(), b.Columns(), nil
+}
+
+// Chunks streams the records of src and calls fn with the parsed column data of every chunkSize rows.
+//
+// The last chunk may hold fewer rows. Types are inferred from the cells of each chunk, see Builder.Flush, so a column that is not described by the schema
+// may be typed differently in different chunks, unless Options.FixTypes is set or the schema comes from InferSchema.
+// Scanning stops at the first error returned by src or fn.
+func Chunks(src Source, chunkSize int, fn func(data map[string][]any, columns []string) error, opts ...Options) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("scan: chunk size must be positive, got %d", chunkSize)
+	}
+
+	columns, err := src.Header()
+	if err != nil {
+		return err
+	}
+
+	b, err := NewBuilder(columns, opts...)
+	if err != nil {
+		return err
+	}
+
+	for {
+		record, err := src.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := b.Append(record); err != nil {
+			return err
+		}
+
+		if b.Buffered() == chunkSize {
+			if err := fn(b.Flush(), b.Columns()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if b.Buffered() > 0 {
+		return fn(b.Flush(), b.Columns())
+	}
+
+	return nil
+}
+
+// InferSchema reads every record of src and returns a field for every column outside the schema of opts, with the type inferred from all its cells.
+//
+// Integers mixed with floating point values are typed as floats, and columns mixing other types as strings. Columns holding only missing values are left out.
+// Chunks given the schema of opts extended with these fields types every column the same way in every chunk.
+func InferSchema(src Source, opts ...Options) (Schema, error) {
+	columns, err := src.Header()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := NewBuilder(columns, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]columnType, len(columns))
+	for {
+		record, err := src.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := b.Append(record); err != nil {
+			return nil, err
+		}
+
+		for _, j := range b.keep {
+			for _, v := range b.data[j] {
+				types[j].add(v)
+			}
+			b.data[j] = b.data[j][:0]
+		}
+	}
+
+	var schema Schema
+	for _, j := range b.keep {
+		if b.field(j) == nil && types[j].seen {
+			schema = append(schema, Field{Name: columns[j], Type: types[j].typ, Nullable: true})
+		}
+	}
+	return schema, nil
+}
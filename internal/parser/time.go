@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// defaultTimeLayouts are tried, after any configured layouts, when inferring time values.
+var defaultTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// excelEpoch is day zero of the Excel 1900 date system, accounting for its fictitious 29 February 1900.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+type timeParser struct {
+	layouts     []string
+	excelSerial bool
+}
+
+func newTimeParser(o Options) timeParser {
+	layouts := make([]string, 0, len(o.TimeLayouts)+len(defaultTimeLayouts))
+	layouts = append(layouts, o.TimeLayouts...)
+	layouts = append(layouts, defaultTimeLayouts...)
+
+	return timeParser{
+		layouts:     layouts,
+		excelSerial: o.ExcelSerialDates,
+	}
+}
+
+// infer parses s with the known layouts. Numbers are never inferred as times.
+func (p timeParser) infer(s string) (time.Time, bool) {
+	if len(s) < 6 {
+		return time.Time{}, false
+	}
+
+	for _, layout := range p.layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// parse parses s for a column declared as time. Besides the known layouts, numbers are read as Excel serial dates when enabled.
+func (p timeParser) parse(s string) (time.Time, bool) {
+	if t, ok := p.infer(s); ok {
+		return t, true
+	}
+
+	if p.excelSerial {
+		if serial, err := strconv.ParseFloat(s, 64); err == nil {
+			return excelSerialTime(serial), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// excelSerialTime converts an Excel serial date, the number of days since the Excel epoch with the time of day as fraction, to a UTC time.
+func excelSerialTime(serial float64) time.Time {
+	days := math.Floor(serial)
+	millis := math.Round((serial - days) * 24 * 60 * 60 * 1000)
+
+	return excelEpoch.AddDate(0, 0, int(days)).Add(time.Duration(millis) * time.Millisecond)
+}
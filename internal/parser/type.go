@@ -3,9 +3,12 @@ package parser
 import (
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/go-rowan/rowan/table"
 )
 
-func inferType(s string) any {
+func inferType(s string, tp timeParser) any {
 	s = strings.TrimSpace(s)
 
 	if result, err := strconv.ParseBool(s); err == nil {
@@ -22,5 +25,44 @@ func inferType(s string) any {
 		return result
 	}
 
+	if result, ok := tp.infer(s); ok {
+		return result
+	}
+
 	return s
 }
+
+// columnType accumulates the type of the values of a column: the type shared by all of them, float for integers mixed with floating point values,
+// and string for other mixes. Missing values are ignored.
+type columnType struct {
+	typ  table.Type
+	seen bool
+}
+
+func (c *columnType) add(v any) {
+	var t table.Type
+	switch v.(type) {
+	case nil:
+		return
+	case int64:
+		t = table.TypeInt
+	case float64:
+		t = table.TypeFloat
+	case bool:
+		t = table.TypeBool
+	case time.Time:
+		t = table.TypeTime
+	default:
+		t = table.TypeString
+	}
+
+	switch {
+	case !c.seen:
+		c.typ, c.seen = t, true
+	case c.typ == t:
+	case c.typ == table.TypeInt && t == table.TypeFloat, c.typ == table.TypeFloat && t == table.TypeInt:
+		c.typ = table.TypeFloat
+	default:
+		c.typ = table.TypeString
+	}
+}
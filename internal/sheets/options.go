@@ -1,8 +1,14 @@
 package sheets
 
+import (
+	"github.com/go-rowan/rowan/internal/parser"
+	"github.com/go-rowan/rowan/table"
+)
+
 type options struct {
 	isURL   bool
 	rangeA1 string
+	parse   parser.Options
 }
 
 type Option func(*options)
@@ -18,3 +24,19 @@ func WithRange(rangeA1 string) Option {
 		o.rangeA1 = rangeA1
 	}
 }
+
+func WithSchema(schema parser.Schema) Option {
+	return func(o *options) {
+		o.parse.Schema = schema
+	}
+}
+
+func WithColumnTypes(types map[string]table.Type) Option {
+	return WithSchema(parser.SchemaFromTypes(types))
+}
+
+func WithTimeLayout(layouts ...string) Option {
+	return func(o *options) {
+		o.parse.TimeLayouts = append(o.parse.TimeLayouts, layouts...)
+	}
+}
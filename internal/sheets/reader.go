@@ -17,7 +17,7 @@ func Read(ctx context.Context, spreadsheet string, argOpts ...Option) (map[strin
 		return nil, nil, err
 	}
 
-	data, err := parser.ParseRows(columns, rows)
+	data, err := parser.ParseRows(columns, rows, source.opts.parse)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -14,6 +14,7 @@ type SheetsSource struct {
 	service       *sheets.Service
 	spreadsheetID string
 	rangeA1       string
+	opts          options
 }
 
 func NewSheetsSource(ctx context.Context, spreadsheet string, argOpts ...Option) (*SheetsSource, error) {
@@ -42,6 +43,7 @@ func NewSheetsSource(ctx context.Context, spreadsheet string, argOpts ...Option)
 		service:       service,
 		spreadsheetID: spreadsheetID,
 		rangeA1:       o.rangeA1,
+		opts:          o,
 	}, nil
 }
 
@@ -69,10 +71,11 @@ func (s *SheetsSource) Read() ([]string, [][]string, error) {
 		return nil, nil, fmt.Errorf("sheets: no columns found")
 	}
 
+	// The API omits trailing empty cells, so short rows are padded with empty cells.
 	rows := make([][]string, len(resp.Values)-1)
 	for i, record := range resp.Values[1:] {
 		nr := len(record)
-		if nr != headersCount {
+		if nr > headersCount {
 			return nil, nil, fmt.Errorf("sheets: row %d has %d columns, expected %d", i+1, nr, headersCount)
 		}
 
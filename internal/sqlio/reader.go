@@ -0,0 +1,213 @@
+// Package sqlio reads the results of database/sql queries into column-oriented data.
+package sqlio
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// kind is the rowan type a database column type maps onto.
+type kind int
+
+const (
+	kindUnknown kind = iota
+	kindInt
+	kindFloat
+	kindBool
+	kindString
+	kindBytes
+	kindTime
+)
+
+// kindOf classifies a database type name, as reported by the driver, with rules similar to the type affinity of SQLite.
+func kindOf(name string) kind {
+	name = strings.ToUpper(name)
+
+	switch {
+	case name == "":
+		return kindUnknown
+	case strings.Contains(name, "INTERVAL"):
+		return kindString
+	case strings.Contains(name, "BOOL") || name == "BIT":
+		return kindBool
+	case strings.Contains(name, "INT") || strings.Contains(name, "SERIAL"):
+		return kindInt
+	case strings.Contains(name, "CHAR") || strings.Contains(name, "TEXT") || strings.Contains(name, "CLOB") ||
+		strings.Contains(name, "UUID") || strings.Contains(name, "JSON") || name == "ENUM" || name == "NAME":
+		return kindString
+	case strings.Contains(name, "BLOB") || strings.Contains(name, "BINARY") || name == "BYTEA":
+		return kindBytes
+	case strings.Contains(name, "REAL") || strings.Contains(name, "FLOA") || strings.Contains(name, "DOUB") ||
+		strings.Contains(name, "DEC") || strings.Contains(name, "NUMERIC") || strings.Contains(name, "MONEY"):
+		return kindFloat
+	case strings.Contains(name, "DATE") || strings.Contains(name, "TIME"):
+		return kindTime
+	default:
+		return kindUnknown
+	}
+}
+
+// timeLayouts are the layouts of times returned as text, tried in order.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Query runs query on db and reads its results, see ReadRows.
+func Query(ctx context.Context, db *sql.DB, query string, args ...any) (map[string][]any, []string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sql: %w", err)
+	}
+
+	return ReadRows(rows)
+}
+
+// ReadRows reads all the rows of rows, then closes it.
+//
+// Values are converted according to the database type of their column: integers of any width become int64, floating point and decimal numbers float64,
+// text string, binary data []byte, and dates and times time.Time. Drivers returning numbers, booleans or times as text, or booleans as integers, are accounted for.
+// Values that can not be converted are kept as returned by the driver, and NULL values are read as nil.
+func ReadRows(rows *sql.Rows) (map[string][]any, []string, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, fmt.Errorf("sql: %w", err)
+	}
+
+	seen := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		if seen[c] {
+			return nil, nil, fmt.Errorf("sql: duplicate column %q, use aliases to name columns uniquely", c)
+		}
+		seen[c] = true
+	}
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("sql: %w", err)
+	}
+
+	kinds := make([]kind, len(columns))
+	for j, ct := range types {
+		kinds[j] = kindOf(ct.DatabaseTypeName())
+	}
+
+	values := make([][]any, len(columns))
+	row := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for j := range dest {
+		dest[j] = &row[j]
+	}
+
+	for n := 1; rows.Next(); n++ {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, fmt.Errorf("sql: row %d: %w", n, err)
+		}
+
+		for j, x := range row {
+			v, err := convert(x, kinds[j])
+			if err != nil {
+				return nil, nil, fmt.Errorf("sql: row %d, column %s: %w", n, columns[j], err)
+			}
+			values[j] = append(values[j], v)
+			row[j] = nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("sql: %w", err)
+	}
+
+	data := make(map[string][]any, len(columns))
+	for j, c := range columns {
+		if values[j] == nil {
+			values[j] = []any{}
+		}
+		data[c] = values[j]
+	}
+
+	return data, columns, nil
+}
+
+// convert converts a value returned by the driver for a column of kind k.
+func convert(x any, k kind) (any, error) {
+	switch v := x.(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		if k == kindBool && (v == 0 || v == 1) {
+			return v == 1, nil
+		}
+		if k == kindFloat {
+			return float64(v), nil
+		}
+		return v, nil
+	case int:
+		return convert(int64(v), k)
+	case int32:
+		return convert(int64(v), k)
+	case int16:
+		return convert(int64(v), k)
+	case int8:
+		return convert(int64(v), k)
+	case uint64:
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d overflows int64", v)
+		}
+		return convert(int64(v), k)
+	case uint32:
+		return convert(int64(v), k)
+	case uint16:
+		return convert(int64(v), k)
+	case uint8:
+		return convert(int64(v), k)
+	case float32:
+		return float64(v), nil
+	case float64, bool, time.Time:
+		return v, nil
+	case []byte:
+		if k == kindBytes || k == kindUnknown {
+			return v, nil
+		}
+		return convertText(string(v), k), nil
+	case string:
+		return convertText(v, k), nil
+	default:
+		return v, nil
+	}
+}
+
+// convertText converts a value returned as text, keeping the text when it does not parse.
+func convertText(s string, k kind) any {
+	switch k {
+	case kindInt:
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+	case kindFloat:
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case kindBool:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	case kindTime:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t
+			}
+		}
+	}
+	return s
+}
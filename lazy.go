@@ -0,0 +1,61 @@
+package rowan
+
+import (
+	"os"
+
+	"github.com/go-rowan/rowan/internal/csv"
+	"github.com/go-rowan/rowan/table"
+)
+
+// LazyTable is an alias of table.LazyTable, a plan of operations executed on Collect.
+type LazyTable = table.LazyTable
+
+// lazyChunkSize is the number of rows parsed at a time when a LazyTable scans a file.
+const lazyChunkSize = 16 * 1024
+
+// LazyCSV returns a LazyTable reading a CSV file, see table.LazyTable.
+//
+// Only the header is read by LazyCSV. When the plan is collected, the file is read in chunks: only the columns used by the plan are parsed,
+// filters that can be applied first are applied to every chunk as it is read, and reading stops as soon as a row limit from First is reached.
+// The file is read again every time the plan is collected.
+//
+// LazyCSV accepts the same CSVOption values as FromCSV. An error is returned if the header can not be read.
+func LazyCSV(path string, opts ...CSVOption) (*LazyTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	columns, err := csv.NewCSVSource(f, opts...).Header()
+	if err != nil {
+		return nil, err
+	}
+
+	return table.NewLazyTable(&csvScanner{
+		path:    path,
+		opts:    opts,
+		columns: append([]string{}, columns...),
+	}), nil
+}
+
+type csvScanner struct {
+	path    string
+	opts    []CSVOption
+	columns []string
+}
+
+func (s *csvScanner) Columns() []string {
+	return s.columns
+}
+
+func (s *csvScanner) Scan(cols []string, emit func(chunk *Table) error) error {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	opts := append(s.opts[:len(s.opts):len(s.opts)], csv.WithColumns(cols...))
+	return csv.Scan(f, lazyChunkSize, chunkHandler(emit), opts...)
+}
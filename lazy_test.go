@@ -0,0 +1,103 @@
+package rowan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeZipCSV writes a CSV file whose zip column holds integers in the first chunk read by LazyCSV, and text after it.
+func writeZipCSV(tb testing.TB) string {
+	tb.Helper()
+
+	var b strings.Builder
+	b.WriteString("id,zip,city\n")
+	for i := 0; i < lazyChunkSize+10; i++ {
+		zip := fmt.Sprintf("%05d", 10000+i%500)
+		if i == lazyChunkSize+5 {
+			zip = "01234A"
+		}
+		fmt.Fprintf(&b, "%d,%s,c%d\n", i, zip, i%7)
+	}
+
+	path := filepath.Join(tb.TempDir(), "zip.csv")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
+
+func TestLazyCSVMatchesFromCSV(t *testing.T) {
+	path := writeZipCSV(t)
+
+	eager, err := FromCSV(path)
+	if err != nil {
+		t.Fatalf("FromCSV: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		lazy  func(*LazyTable) *LazyTable
+		eager func(*Table) (*Table, error)
+	}{
+		{
+			name:  "filter on a column changing type",
+			lazy:  func(l *LazyTable) *LazyTable { return l.Filter("zip == '01234A'") },
+			eager: func(t *Table) (*Table, error) { return t.Filter("zip == '01234A'") },
+		},
+		{
+			name: "filter with limit",
+			lazy: func(l *LazyTable) *LazyTable {
+				return l.Filter("zip == 10003 or zip == '01234A'").First(40).Select("id")
+			},
+			eager: func(t *Table) (*Table, error) {
+				t, err := t.Filter("zip == 10003 or zip == '01234A'")
+				if err != nil {
+					return nil, err
+				}
+				return t.First(40).Select("id")
+			},
+		},
+		{
+			name: "filter on a column of one type",
+			lazy: func(l *LazyTable) *LazyTable { return l.Filter("city == 'c3' && id > 16380").Select("id", "city") },
+			eager: func(t *Table) (*Table, error) {
+				t, err := t.Filter("city == 'c3' && id > 16380")
+				if err != nil {
+					return nil, err
+				}
+				return t.Select("id", "city")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := tt.eager(eager)
+			if err != nil {
+				t.Fatalf("eager: %v", err)
+			}
+
+			l, err := LazyCSV(path)
+			if err != nil {
+				t.Fatalf("LazyCSV: %v", err)
+			}
+			got, err := tt.lazy(l).Collect()
+			if err != nil {
+				t.Fatalf("Collect: %v", err)
+			}
+
+			if !reflect.DeepEqual(got.Columns(), want.Columns()) || got.Len() != want.Len() {
+				t.Fatalf("got %v with %d rows, want %v with %d rows", got.Columns(), got.Len(), want.Columns(), want.Len())
+			}
+			for _, c := range want.Columns() {
+				if g, w := got.MustCol(c).Values(), want.MustCol(c).Values(); !reflect.DeepEqual(g, w) {
+					t.Errorf("column %s = %v, want %v", c, g, w)
+				}
+			}
+		})
+	}
+}
@@ -13,6 +13,58 @@ func WithDelimiter(r rune) CSVOption {
 	return csv.WithDelimiter(r)
 }
 
+// WithNoHeader returns a CSVOption for files without a header row.
+//
+// Every record, including the first one, is read as data and the columns are named col_0, col_1, ... in order.
+func WithNoHeader() CSVOption {
+	return csv.WithNoHeader()
+}
+
+// WithColumnNames returns a CSVOption for files without a header row that uses the given names for the columns.
+//
+// Every record, including the first one, is read as data. Records are matched against the number of names given, subject to WithRaggedRows.
+func WithColumnNames(names ...string) CSVOption {
+	return csv.WithColumnNames(names...)
+}
+
+// WithSkipRows returns a CSVOption that discards the first n lines of the file before the header is read.
+//
+// This is useful for exports that start with banner or title lines. Skipped lines do not need to be valid CSV.
+func WithSkipRows(n int) CSVOption {
+	return csv.WithSkipRows(n)
+}
+
+// WithComment returns a CSVOption that ignores every line starting with the given rune, such as '#'.
+func WithComment(r rune) CSVOption {
+	return csv.WithComment(r)
+}
+
+// WithLazyQuotes returns a CSVOption that tolerates quotes appearing in unquoted fields and non-doubled quotes in quoted fields.
+func WithLazyQuotes() CSVOption {
+	return csv.WithLazyQuotes()
+}
+
+// WithNullValues returns a CSVOption that parses cells matching any of the given tokens, such as "NA", "NULL" or "-", as nil.
+//
+// Cells are compared after trimming surrounding spaces. Empty cells are always parsed as nil.
+func WithNullValues(tokens ...string) CSVOption {
+	return csv.WithNullValues(tokens...)
+}
+
+// WithRaggedRows returns a CSVOption that sets the policy for rows whose number of fields differs from the number of columns.
+//
+// See RaggedError, RaggedPad and RaggedDrop.
+func WithRaggedRows(policy RaggedPolicy) CSVOption {
+	return csv.WithRaggedRows(policy)
+}
+
+// WithTimeLayout returns a CSVOption that adds layouts, as accepted by time.Parse, used to recognize time values.
+//
+// The given layouts are tried before the default ones: RFC 3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05" and "2006-01-02".
+func WithTimeLayout(layouts ...string) CSVOption {
+	return csv.WithTimeLayout(layouts...)
+}
+
 // WithSheetsURL configures FromSheets to treat the spreadsheet argument as a full Google Sheets URL instead of a raw spreadsheet ID.
 func WithSheetsURL() SheetsOption {
 	return sheets.WithSheetsURL()
@@ -27,3 +79,13 @@ func WithSheetsRange(rangeA1 string) SheetsOption {
 func WithExcelRange(rangeA1 string) ExcelOption {
 	return excel.WithRange(rangeA1)
 }
+
+// WithExcelTimeLayout adds layouts, as accepted by time.Parse, used to recognize time values in cells that are not formatted as Excel dates.
+func WithExcelTimeLayout(layouts ...string) ExcelOption {
+	return excel.WithTimeLayout(layouts...)
+}
+
+// WithSheetsTimeLayout adds layouts, as accepted by time.Parse, used to recognize time values.
+func WithSheetsTimeLayout(layouts ...string) SheetsOption {
+	return sheets.WithTimeLayout(layouts...)
+}
@@ -0,0 +1,113 @@
+package rowan
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSVOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		opts    []CSVOption
+		columns []string
+		values  map[string][]any
+	}{
+		{
+			name:    "delimiter",
+			input:   "a|b\n1|x\n",
+			opts:    []CSVOption{WithDelimiter('|')},
+			columns: []string{"a", "b"},
+			values:  map[string][]any{"a": {int64(1)}, "b": {"x"}},
+		},
+		{
+			name:    "no header",
+			input:   "1,x\n2,y\n",
+			opts:    []CSVOption{WithNoHeader()},
+			columns: []string{"col_0", "col_1"},
+			values:  map[string][]any{"col_0": {int64(1), int64(2)}, "col_1": {"x", "y"}},
+		},
+		{
+			name:    "column names",
+			input:   "1,x\n2,y\n",
+			opts:    []CSVOption{WithColumnNames("id", "code")},
+			columns: []string{"id", "code"},
+			values:  map[string][]any{"id": {int64(1), int64(2)}, "code": {"x", "y"}},
+		},
+		{
+			name:    "skip rows",
+			input:   "Report generated today\n\"unbalanced\n,,\na,b\n1,2\n",
+			opts:    []CSVOption{WithSkipRows(3)},
+			columns: []string{"a", "b"},
+			values:  map[string][]any{"a": {int64(1)}, "b": {int64(2)}},
+		},
+		{
+			name:    "comment",
+			input:   "# exported\na,b\n1,2\n# trailer\n",
+			opts:    []CSVOption{WithComment('#')},
+			columns: []string{"a", "b"},
+			values:  map[string][]any{"a": {int64(1)}, "b": {int64(2)}},
+		},
+		{
+			name:    "lazy quotes",
+			input:   "a,b\n1,say \"hi\"\n",
+			opts:    []CSVOption{WithLazyQuotes()},
+			columns: []string{"a", "b"},
+			values:  map[string][]any{"a": {int64(1)}, "b": {`say "hi"`}},
+		},
+		{
+			name:    "null values",
+			input:   "a,b\nNA,1\n - ,NULL\n3,x\n",
+			opts:    []CSVOption{WithNullValues("NA", "NULL", "-")},
+			columns: []string{"a", "b"},
+			values:  map[string][]any{"a": {nil, nil, int64(3)}, "b": {int64(1), nil, "x"}},
+		},
+		{
+			name:    "ragged pad",
+			input:   "a,b,c\n1,2\n4,5,6\n",
+			opts:    []CSVOption{WithRaggedRows(RaggedPad)},
+			columns: []string{"a", "b", "c"},
+			values:  map[string][]any{"a": {int64(1), int64(4)}, "c": {nil, int64(6)}},
+		},
+		{
+			name:    "ragged drop",
+			input:   "a,b\n1\n2,3\n4,5,6\n",
+			opts:    []CSVOption{WithRaggedRows(RaggedDrop)},
+			columns: []string{"a", "b"},
+			values:  map[string][]any{"a": {int64(2)}, "b": {int64(3)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl, err := FromCSVReader(strings.NewReader(tt.input), tt.opts...)
+			if err != nil {
+				t.Fatalf("FromCSVReader: %v", err)
+			}
+			if !reflect.DeepEqual(tbl.Columns(), tt.columns) {
+				t.Fatalf("columns = %v, want %v", tbl.Columns(), tt.columns)
+			}
+			for c, want := range tt.values {
+				if got := tbl.MustCol(c).Values(); !reflect.DeepEqual(got, want) {
+					t.Errorf("column %s = %#v, want %#v", c, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCSVRaggedRowsError(t *testing.T) {
+	_, err := FromCSVReader(strings.NewReader("a,b\n1,2\n3\n"))
+	if err == nil {
+		t.Fatal("FromCSVReader: expected an error for a short row")
+	}
+	if !strings.Contains(err.Error(), "row 2") {
+		t.Errorf("error %q does not name the row", err)
+	}
+
+	_, err = FromCSVReader(strings.NewReader("a,b\n1,2,3\n"), WithRaggedRows(RaggedPad))
+	if err == nil {
+		t.Fatal("FromCSVReader with RaggedPad: expected an error for a long row")
+	}
+}
@@ -9,6 +9,9 @@ import "github.com/go-rowan/rowan/table"
 // It contains the column names, the underlying data per column, and the number of rows.
 type Table = table.Table
 
+// ErrFrozen is returned by the methods mutating a Table when the Table is frozen, see table.Table.Freeze.
+var ErrFrozen = table.ErrFrozen
+
 // New creates a new Table from the given column-oriented data.
 // This function is a convenience wrapper around table.New.
 //
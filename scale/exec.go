@@ -0,0 +1,67 @@
+package scale
+
+import (
+	"context"
+
+	"github.com/go-rowan/rowan/internal/parallel"
+	"github.com/go-rowan/rowan/table"
+)
+
+// fitColumns calls fit for every column of t in parallel, and returns the results in the order of columns.
+//
+// If fitting a column fails, the results of the columns before it are returned along with the error, as a serial loop would have computed them.
+func fitColumns[T any](ctx context.Context, t *table.Table, columns []string, fit func(col *table.Column) (T, error)) ([]T, error) {
+	results := make([]T, len(columns))
+	fitted := make([]bool, len(columns))
+
+	err := parallel.For(ctx, len(columns), func(i int) error {
+		col, err := t.Col(columns[i])
+		if err != nil {
+			return err
+		}
+
+		if results[i], err = fit(col); err != nil {
+			return err
+		}
+		fitted[i] = true
+		return nil
+	})
+
+	n := 0
+	for n < len(columns) && fitted[n] {
+		n++
+	}
+	return results[:n], err
+}
+
+// transformColumns returns a copy of t where the numeric values of every feature are mapped with the function returned by scaling for that feature.
+// The features are transformed in parallel.
+func transformColumns(ctx context.Context, t *table.Table, features []string, scaling func(feat string) (func(float64) float64, error)) (*table.Table, error) {
+	mapped := make([]*table.Column, len(features))
+
+	err := parallel.For(ctx, len(features), func(i int) error {
+		col, err := t.Col(features[i])
+		if err != nil {
+			return err
+		}
+
+		f, err := scaling(features[i])
+		if err != nil {
+			return err
+		}
+
+		mapped[i] = col.MapFloat(f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := t.Clone()
+	for _, col := range mapped {
+		if err := result.SetCol(col); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
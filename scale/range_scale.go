@@ -1,9 +1,9 @@
 package scale
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/go-rowan/rowan/internal/numeric"
 	"github.com/go-rowan/rowan/table"
 )
 
@@ -53,29 +53,37 @@ func NewRangeScaler() *RangeScaler {
 //   - Fit must be called before Transform.
 //   - Calling Fit multiple times overwrites previously stored statistics for the specified columns.
 func (s *RangeScaler) Fit(t *table.Table, columns ...string) error {
+	return s.FitContext(context.Background(), t, columns...)
+}
+
+// FitContext is like Fit, but stops fitting columns and returns ctx.Err() when ctx is done before all columns have been processed.
+// Columns are fitted in parallel, see table.SetWorkers.
+func (s *RangeScaler) FitContext(ctx context.Context, t *table.Table, columns ...string) error {
 	if t == nil {
 		return fmt.Errorf("fit: table is nil")
 	}
 
-	for _, c := range columns {
-		col, err := t.Col(c)
-		if err != nil {
-			return err
-		}
+	type bounds struct{ min, max float64 }
 
+	results, err := fitColumns(ctx, t, columns, func(col *table.Column) (bounds, error) {
 		min, ok := col.Min()
 		if !ok {
-			return fmt.Errorf("fit: column has no numeric values")
+			return bounds{}, fmt.Errorf("fit: column has no numeric values")
 		}
 		max, _ := col.Max()
 
-		s.min[c] = min
-		s.max[c] = max
+		return bounds{min: min, max: max}, nil
+	})
+
+	for i, r := range results {
+		c := columns[i]
+		s.min[c] = r.min
+		s.max[c] = r.max
 
 		s.features = append(s.features, c)
 	}
 
-	return nil
+	return err
 }
 
 // Transform applies range-based scaling to the specified columns of a Table.
@@ -97,6 +105,12 @@ func (s *RangeScaler) Fit(t *table.Table, columns ...string) error {
 //     (x - min) / (max - min)
 //   - Columns with zero range (min == max) result in an error to avoid division by zero.
 func (s *RangeScaler) Transform(t *table.Table, columns ...string) (*table.Table, error) {
+	return s.TransformContext(context.Background(), t, columns...)
+}
+
+// TransformContext is like Transform, but stops transforming columns and returns ctx.Err() when ctx is done before all columns have been processed.
+// Columns are transformed in parallel, see table.SetWorkers.
+func (s *RangeScaler) TransformContext(ctx context.Context, t *table.Table, columns ...string) (*table.Table, error) {
 	if t == nil {
 		return nil, fmt.Errorf("transform: table is nil")
 	}
@@ -109,14 +123,7 @@ func (s *RangeScaler) Transform(t *table.Table, columns ...string) (*table.Table
 		return nil, fmt.Errorf("transform: no columns specified and scaler has no fitted features")
 	}
 
-	result := t.Clone()
-
-	for _, feat := range features {
-		col, err := result.Col(feat)
-		if err != nil {
-			return nil, err
-		}
-
+	return transformColumns(ctx, t, features, func(feat string) (func(float64) float64, error) {
 		min, okMin := s.min[feat]
 		max, okMax := s.max[feat]
 		if !okMin || !okMax {
@@ -128,20 +135,10 @@ func (s *RangeScaler) Transform(t *table.Table, columns ...string) (*table.Table
 			return nil, fmt.Errorf("transform: cannot scale column with zero range")
 		}
 
-		mapped := col.Map(func(v any) any {
-			f, ok := numeric.ToFloat64(v)
-			if !ok {
-				return v
-			}
+		return func(f float64) float64 {
 			return (f - min) / r
-		})
-
-		if err := result.ReplaceColumn(feat, mapped.Values()); err != nil {
-			return nil, err
-		}
-	}
-
-	return result, nil
+		}, nil
+	})
 }
 
 // Features returns the list of column names that were fitted by the scaler.
@@ -0,0 +1,159 @@
+package scale
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/go-rowan/rowan/table"
+)
+
+func scaleTable(tb testing.TB) *table.Table {
+	tb.Helper()
+
+	t, err := table.New(map[string][]any{
+		"a":    {1.0, 2.0, 3.0, nil, 5.0},
+		"b":    {int64(10), int64(20), int64(30), int64(40), int64(50)},
+		"name": {"v", "w", "x", "y", "z"},
+	}, []string{"a", "b", "name"})
+	if err != nil {
+		tb.Fatalf("New: %v", err)
+	}
+	return t
+}
+
+func TestRangeScaler(t *testing.T) {
+	tbl := scaleTable(t)
+
+	s := NewRangeScaler()
+	if err := s.Fit(tbl, "a", "b"); err != nil {
+		t.Fatalf("Fit: %v", err)
+	}
+	got, err := s.Transform(tbl)
+	if err != nil {
+		t.Fatalf("Transform: %v", err)
+	}
+
+	want := map[string][]any{
+		"a": {0.0, 0.25, 0.5, nil, 1.0},
+		"b": {0.0, 0.25, 0.5, 0.75, 1.0},
+	}
+	for c, values := range want {
+		assertFloats(t, got, c, values)
+	}
+	if v := tbl.MustCol("b").At(0); v != int64(10) {
+		t.Errorf("Transform modified the input table: b[0] = %v", v)
+	}
+}
+
+func TestZScaler(t *testing.T) {
+	tbl := scaleTable(t)
+
+	s := NewZScaler()
+	if err := s.Fit(tbl, "b"); err != nil {
+		t.Fatalf("Fit: %v", err)
+	}
+	if mean, ok := s.Mean("b"); !ok || mean != 30 {
+		t.Errorf("Mean(b) = %v, %v, want 30", mean, ok)
+	}
+
+	got, err := s.Transform(tbl)
+	if err != nil {
+		t.Fatalf("Transform: %v", err)
+	}
+	std, _ := s.Std("b")
+	assertFloats(t, got, "b", []any{-20 / std, -10 / std, 0.0, 10 / std, 20 / std})
+}
+
+func TestTransformErrors(t *testing.T) {
+	tbl := scaleTable(t)
+
+	fitted := NewRangeScaler()
+	if err := fitted.Fit(tbl, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		transform func() error
+	}{
+		{"range not fitted", func() error { _, err := NewRangeScaler().Transform(tbl); return err }},
+		{"z not fitted", func() error { _, err := NewZScaler().Transform(tbl); return err }},
+		{"nil table", func() error { _, err := NewRangeScaler().Transform(nil, "a"); return err }},
+		{"column not fitted", func() error { _, err := fitted.Transform(tbl, "b"); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.transform(); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestConcurrentTransform(t *testing.T) {
+	tbl := scaleTable(t).Freeze()
+
+	rs := NewRangeScaler()
+	if err := rs.Fit(tbl, "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	zs := NewZScaler()
+	if err := zs.Fit(tbl, "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	const goroutines = 8
+	results := make([]*table.Table, goroutines)
+	errs := make([]error, goroutines)
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var s Scaler = rs
+			if g%2 == 1 {
+				s = zs
+			}
+			if _, errs[g] = tbl.Col("a"); errs[g] != nil {
+				return
+			}
+			if _, errs[g] = tbl.Where(func(row map[string]any) bool { return row["a"] != nil }); errs[g] != nil {
+				return
+			}
+			results[g], errs[g] = s.Transform(tbl)
+		}()
+	}
+	wg.Wait()
+
+	for g, err := range errs {
+		if err != nil {
+			t.Fatalf("goroutine %d: %v", g, err)
+		}
+	}
+	for g := 2; g < goroutines; g++ {
+		assertFloats(t, results[g], "b", results[g%2].MustCol("b").Values())
+	}
+	assertFloats(t, results[0], "b", []any{0.0, 0.25, 0.5, 0.75, 1.0})
+}
+
+// assertFloats checks the values of column c of t, comparing floats with a small tolerance.
+func assertFloats(tb testing.TB, t *table.Table, c string, want []any) {
+	tb.Helper()
+
+	got := t.MustCol(c).Values()
+	if len(got) != len(want) {
+		tb.Fatalf("column %s = %v, want %v", c, got, want)
+	}
+	for i := range want {
+		g, gok := got[i].(float64)
+		w, wok := want[i].(float64)
+		if gok != wok || (gok && math.Abs(g-w) > 1e-9) || (!gok && got[i] != want[i]) {
+			tb.Errorf("column %s = %v, want %v", c, got, want)
+			return
+		}
+	}
+}
@@ -1,9 +1,9 @@
 package scale
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/go-rowan/rowan/internal/numeric"
 	"github.com/go-rowan/rowan/table"
 )
 
@@ -45,33 +45,41 @@ func NewZScaler() *ZScaler {
 //
 // Fit stores the computed statistics internally and overwrites any previously fitted values for the same columns.
 func (s *ZScaler) Fit(t *table.Table, columns ...string) error {
+	return s.FitContext(context.Background(), t, columns...)
+}
+
+// FitContext is like Fit, but stops fitting columns and returns ctx.Err() when ctx is done before all columns have been processed.
+// Columns are fitted in parallel, see table.SetWorkers.
+func (s *ZScaler) FitContext(ctx context.Context, t *table.Table, columns ...string) error {
 	if t == nil {
 		return fmt.Errorf("fit: table is nil")
 	}
 
-	for _, c := range columns {
-		col, err := t.Col(c)
-		if err != nil {
-			return err
-		}
+	type stats struct{ mean, std float64 }
 
+	results, err := fitColumns(ctx, t, columns, func(col *table.Column) (stats, error) {
 		mean, ok := col.Mean()
 		if !ok {
-			return fmt.Errorf("fit: column %s has no numeric values", c)
+			return stats{}, fmt.Errorf("fit: column %s has no numeric values", col.Name())
 		}
 
 		std, ok := col.Std()
 		if !ok || std == 0 {
-			return fmt.Errorf("fit: column %s has zero standard deviation", c)
+			return stats{}, fmt.Errorf("fit: column %s has zero standard deviation", col.Name())
 		}
 
-		s.mean[c] = mean
-		s.std[c] = std
+		return stats{mean: mean, std: std}, nil
+	})
+
+	for i, r := range results {
+		c := columns[i]
+		s.mean[c] = r.mean
+		s.std[c] = r.std
 
 		s.features = append(s.features, c)
 	}
 
-	return nil
+	return err
 }
 
 // Transform applies Z-score standardization to the specified columns using statistics computed during Fit.
@@ -88,6 +96,12 @@ func (s *ZScaler) Fit(t *table.Table, columns ...string) error {
 //
 // Transform returns an error if Fit has not been called for a column or if the stored standard deviation is zero.
 func (s *ZScaler) Transform(t *table.Table, columns ...string) (*table.Table, error) {
+	return s.TransformContext(context.Background(), t, columns...)
+}
+
+// TransformContext is like Transform, but stops transforming columns and returns ctx.Err() when ctx is done before all columns have been processed.
+// Columns are transformed in parallel, see table.SetWorkers.
+func (s *ZScaler) TransformContext(ctx context.Context, t *table.Table, columns ...string) (*table.Table, error) {
 	if t == nil {
 		return nil, fmt.Errorf("transform: table is nil")
 	}
@@ -100,14 +114,7 @@ func (s *ZScaler) Transform(t *table.Table, columns ...string) (*table.Table, er
 		return nil, fmt.Errorf("transform: no columns specified and scaler has no fitted features")
 	}
 
-	result := t.Clone()
-
-	for _, feat := range features {
-		col, err := result.Col(feat)
-		if err != nil {
-			return nil, err
-		}
-
+	return transformColumns(ctx, t, features, func(feat string) (func(float64) float64, error) {
 		mean, okMean := s.mean[feat]
 		std, okStd := s.std[feat]
 		if !okMean || !okStd {
@@ -118,20 +125,10 @@ func (s *ZScaler) Transform(t *table.Table, columns ...string) (*table.Table, er
 			return nil, fmt.Errorf("transform: cannot standardize column %s with zero std", feat)
 		}
 
-		mapped := col.Map(func(v any) any {
-			f, ok := numeric.ToFloat64(v)
-			if !ok {
-				return v
-			}
+		return func(f float64) float64 {
 			return (f - mean) / std
-		})
-
-		if err := result.ReplaceColumn(feat, mapped.Values()); err != nil {
-			return nil, err
-		}
-	}
-
-	return result, nil
+		}, nil
+	})
 }
 
 // Features returns the list of column names that were fitted by the scaler.
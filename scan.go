@@ -0,0 +1,52 @@
+package rowan
+
+import (
+	"io"
+
+	"github.com/go-rowan/rowan/internal/csv"
+	"github.com/go-rowan/rowan/internal/excel"
+	"github.com/go-rowan/rowan/table"
+)
+
+// ScanCSV reads a CSV file in batches of chunkSize rows and calls fn with each batch as a Table.
+//
+// Only one batch is held in memory at a time, which allows processing files that are larger than the available memory. The last batch may contain fewer rows.
+//
+// Every column has the same type in every batch. The file is read twice: first to infer the type of every column from all its cells, then to read the batches.
+// The types are the ones FromCSV infers, except that a column mixing values of several types, such as postal codes holding 12345 and 01234A, is read as strings.
+// Columns declared with WithColumnTypes or WithSchema keep their declared types.
+//
+// Scanning stops at the first error, either from reading the file or returned by fn, and that error is returned.
+func ScanCSV(path string, chunkSize int, fn func(*Table) error, opts ...CSVOption) error {
+	return csv.ScanFile(path, chunkSize, chunkHandler(fn), opts...)
+}
+
+// ScanCSVReader reads CSV data from r in batches of chunkSize rows and calls fn with each batch as a Table.
+//
+// It behaves like ScanCSV but accepts any io.Reader, which is read only once. The type of a column is therefore fixed by the first batch holding values of the column:
+// integers mixed with floats in that batch are read as floats, and other mixes as strings. The cells of later batches are converted to that type,
+// and a cell that can not be converted, such as 2.5 in a column of integers, is reported as an error. Declare the types of such columns with WithColumnTypes or WithSchema.
+//
+// The caller remains responsible for closing r.
+func ScanCSVReader(r io.Reader, chunkSize int, fn func(*Table) error, opts ...CSVOption) error {
+	opts = append(opts[:len(opts):len(opts)], csv.WithFixedTypes())
+	return csv.Scan(r, chunkSize, chunkHandler(fn), opts...)
+}
+
+// ScanExcel reads a sheet of an Excel file in batches of chunkSize rows and calls fn with each batch as a Table.
+//
+// The first row of the sheet is treated as the header. It behaves like ScanCSV, reading the sheet twice, and accepts the same ExcelOption values as FromExcel.
+func ScanExcel(path string, chunkSize int, fn func(*Table) error, opts ...ExcelOption) error {
+	return excel.Scan(path, chunkSize, chunkHandler(fn), opts...)
+}
+
+func chunkHandler(fn func(*Table) error) func(map[string][]any, []string) error {
+	return func(data map[string][]any, columns []string) error {
+		tbl, err := table.New(data, columns)
+		if err != nil {
+			return err
+		}
+
+		return fn(tbl)
+	}
+}
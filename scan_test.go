@@ -0,0 +1,196 @@
+package rowan
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-rowan/rowan/table"
+)
+
+func TestScanCSVReader(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("id,value\n")
+	for i := 1; i <= 10; i++ {
+		fmt.Fprintf(&b, "%d,%d\n", i, i*10)
+	}
+	input := b.String()
+
+	tests := []struct {
+		chunkSize int
+		sizes     []int
+	}{
+		{chunkSize: 3, sizes: []int{3, 3, 3, 1}},
+		{chunkSize: 5, sizes: []int{5, 5}},
+		{chunkSize: 100, sizes: []int{10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.chunkSize), func(t *testing.T) {
+			sizes := []int{}
+			ids := []any{}
+
+			err := ScanCSVReader(strings.NewReader(input), tt.chunkSize, func(chunk *Table) error {
+				sizes = append(sizes, chunk.Len())
+				ids = append(ids, chunk.MustCol("id").Values()...)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ScanCSVReader: %v", err)
+			}
+
+			if !reflect.DeepEqual(sizes, tt.sizes) {
+				t.Errorf("chunk sizes = %v, want %v", sizes, tt.sizes)
+			}
+			if len(ids) != 10 || ids[0] != int64(1) || ids[9] != int64(10) {
+				t.Errorf("ids = %v", ids)
+			}
+		})
+	}
+}
+
+func TestScanCSVTypesAreFixed(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		reader bool
+		types  []Type
+		values []any
+		err    string
+	}{
+		{
+			name:   "float after the first chunk",
+			input:  "x\n1\n2\n2.5\n3\n4\n",
+			types:  []Type{TypeFloat, TypeFloat, TypeFloat},
+			values: []any{1.0, 2.0, 2.5, 3.0, 4.0},
+		},
+		{
+			name:   "text after the first chunk",
+			input:  "zip\n12345\n02345\n01234A\n",
+			types:  []Type{TypeString, TypeString},
+			values: []any{"12345", "02345", "01234A"},
+		},
+		{
+			name:   "reader with a float after the first chunk",
+			input:  "x\n1\n2\n2.5\n3\n4\n",
+			reader: true,
+			err:    `row 3 column x: cannot convert "2.5" to int`,
+		},
+		{
+			name:   "reader with text after the first chunk",
+			input:  "zip\n12345\n02345\n01234A\n",
+			reader: true,
+			err:    `row 3 column zip: cannot convert "01234A" to int`,
+		},
+		{
+			name:   "reader with mixed values in the first chunk",
+			input:  "zip\n12345\n01234A\n007\n",
+			reader: true,
+			types:  []Type{TypeString, TypeString},
+			values: []any{"12345", "01234A", "007"},
+		},
+		{
+			name:   "reader with floats in the first chunk",
+			input:  "x\n1\n2.5\n3\n",
+			reader: true,
+			types:  []Type{TypeFloat, TypeFloat},
+			values: []any{1.0, 2.5, 3.0},
+		},
+		{
+			name:   "reader with missing values in the first chunk",
+			input:  "x,y\n,a\n,b\n1.5,c\n2,d\n",
+			reader: true,
+			types:  []Type{table.TypeUnknown, TypeFloat},
+			values: []any{nil, nil, 1.5, 2.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types := []Type{}
+			values := []any{}
+			fn := func(chunk *Table) error {
+				col := chunk.MustCol(chunk.Columns()[0])
+				types = append(types, col.Type())
+				values = append(values, col.Values()...)
+				return nil
+			}
+
+			var err error
+			if tt.reader {
+				err = ScanCSVReader(strings.NewReader(tt.input), 2, fn)
+			} else {
+				path := filepath.Join(t.TempDir(), "data.csv")
+				if err := os.WriteFile(path, []byte(tt.input), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				err = ScanCSV(path, 2, fn)
+			}
+
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want it to contain %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("scan: %v", err)
+			}
+			if !reflect.DeepEqual(types, tt.types) {
+				t.Errorf("chunk types = %v, want %v", types, tt.types)
+			}
+			if !reflect.DeepEqual(values, tt.values) {
+				t.Errorf("values = %#v, want %#v", values, tt.values)
+			}
+		})
+	}
+}
+
+func TestScanCSVColumnTypesAreConsistent(t *testing.T) {
+	input := "zip\n12345\n23456\n01234A\n"
+
+	types := []Type{}
+	err := ScanCSVReader(strings.NewReader(input), 2, func(chunk *Table) error {
+		types = append(types, chunk.MustCol("zip").Type())
+		return nil
+	}, WithColumnTypes(map[string]Type{"zip": TypeString}))
+	if err != nil {
+		t.Fatalf("ScanCSVReader: %v", err)
+	}
+
+	want := []Type{TypeString, TypeString}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("chunk types = %v, want %v", types, want)
+	}
+}
+
+func TestScanCSVStopsOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte("a\n1\n2\n3\n4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err := ScanCSV(path, 1, func(*Table) error {
+		calls++
+		if calls == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("ScanCSV error = %v, want %v", err, stop)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+
+	if err := ScanCSV(path, 0, func(*Table) error { return nil }); err == nil {
+		t.Error("ScanCSV with chunk size 0: expected an error")
+	}
+}
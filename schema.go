@@ -0,0 +1,77 @@
+package rowan
+
+import (
+	"github.com/go-rowan/rowan/internal/csv"
+	"github.com/go-rowan/rowan/internal/excel"
+	"github.com/go-rowan/rowan/internal/parser"
+	"github.com/go-rowan/rowan/internal/sheets"
+	"github.com/go-rowan/rowan/table"
+)
+
+// Type is an alias of table.Type, which identifies the kind of values held by a column.
+type Type = table.Type
+
+// Column types that can be declared in a Schema.
+const (
+	TypeBool   = table.TypeBool
+	TypeInt    = table.TypeInt
+	TypeFloat  = table.TypeFloat
+	TypeString = table.TypeString
+	TypeTime   = table.TypeTime
+)
+
+// Field is an alias of parser.Field, which describes the expected type of a single column.
+//
+// A Field holds the column name, the Type its values are converted to, whether missing values are allowed (Nullable), and the layout used to parse TypeTime values (Format).
+type Field = parser.Field
+
+// Schema is an alias of parser.Schema, which lists the expected types of the columns of a source.
+//
+// When a Schema is provided, values of the listed columns are converted to the declared type instead of being inferred, so values such as the ZIP code "01234" can be kept as strings.
+// Columns that are not listed keep their inferred types. A value that cannot be converted is reported as an error naming the row number and the column.
+//
+// Example:
+//
+//	schema := rowan.Schema{
+//	    {Name: "zip", Type: rowan.TypeString},
+//	    {Name: "amount", Type: rowan.TypeFloat, Nullable: true},
+//	}
+//
+//	tbl, err := rowan.FromCSV("data.csv", rowan.WithSchema(schema))
+type Schema = parser.Schema
+
+// WithSchema returns a CSVOption that converts the columns listed in schema to their declared types.
+func WithSchema(schema Schema) CSVOption {
+	return csv.WithSchema(schema)
+}
+
+// WithColumnTypes returns a CSVOption that converts the given columns to the given types.
+//
+// It is a shortcut for WithSchema with nullable fields and default formats.
+func WithColumnTypes(types map[string]Type) CSVOption {
+	return csv.WithColumnTypes(types)
+}
+
+// WithExcelSchema returns an ExcelOption that converts the columns listed in schema to their declared types.
+func WithExcelSchema(schema Schema) ExcelOption {
+	return excel.WithSchema(schema)
+}
+
+// WithExcelColumnTypes returns an ExcelOption that converts the given columns to the given types.
+//
+// It is a shortcut for WithExcelSchema with nullable fields and default formats.
+func WithExcelColumnTypes(types map[string]Type) ExcelOption {
+	return excel.WithColumnTypes(types)
+}
+
+// WithSheetsSchema returns a SheetsOption that converts the columns listed in schema to their declared types.
+func WithSheetsSchema(schema Schema) SheetsOption {
+	return sheets.WithSchema(schema)
+}
+
+// WithSheetsColumnTypes returns a SheetsOption that converts the given columns to the given types.
+//
+// It is a shortcut for WithSheetsSchema with nullable fields and default formats.
+func WithSheetsColumnTypes(types map[string]Type) SheetsOption {
+	return sheets.WithColumnTypes(types)
+}
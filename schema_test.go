@@ -0,0 +1,141 @@
+package rowan
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVSchema(t *testing.T) {
+	const input = "zip,amount,active,joined\n01234,10,true,2024-01-31\n56789,,false,2024-02-29\n"
+
+	tests := []struct {
+		name   string
+		opts   []CSVOption
+		column string
+		typ    Type
+		values []any
+	}{
+		{
+			name:   "inferred zip",
+			column: "zip",
+			typ:    TypeInt,
+			values: []any{int64(1234), int64(56789)},
+		},
+		{
+			name:   "string zip",
+			opts:   []CSVOption{WithColumnTypes(map[string]Type{"zip": TypeString})},
+			column: "zip",
+			typ:    TypeString,
+			values: []any{"01234", "56789"},
+		},
+		{
+			name:   "float amount",
+			opts:   []CSVOption{WithColumnTypes(map[string]Type{"amount": TypeFloat})},
+			column: "amount",
+			typ:    TypeFloat,
+			values: []any{10.0, nil},
+		},
+		{
+			name:   "bool",
+			opts:   []CSVOption{WithSchema(Schema{{Name: "active", Type: TypeBool}})},
+			column: "active",
+			typ:    TypeBool,
+			values: []any{true, false},
+		},
+		{
+			name:   "time with format",
+			opts:   []CSVOption{WithSchema(Schema{{Name: "joined", Type: TypeTime, Format: "2006-01-02"}})},
+			column: "joined",
+			typ:    TypeTime,
+			values: []any{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl, err := FromCSVReader(strings.NewReader(input), tt.opts...)
+			if err != nil {
+				t.Fatalf("FromCSVReader: %v", err)
+			}
+
+			col := tbl.MustCol(tt.column)
+			if col.Type() != tt.typ {
+				t.Errorf("type = %v, want %v", col.Type(), tt.typ)
+			}
+			if got := col.Values(); !reflect.DeepEqual(got, tt.values) {
+				t.Errorf("values = %#v, want %#v", got, tt.values)
+			}
+		})
+	}
+}
+
+func TestCSVSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  []CSVOption
+		want  string
+	}{
+		{
+			name:  "unknown column",
+			input: "a\n1\n",
+			opts:  []CSVOption{WithColumnTypes(map[string]Type{"b": TypeInt})},
+			want:  "column b not found",
+		},
+		{
+			name:  "conversion",
+			input: "a\n1\nx\n",
+			opts:  []CSVOption{WithColumnTypes(map[string]Type{"a": TypeInt})},
+			want:  "row 2",
+		},
+		{
+			name:  "not nullable",
+			input: "a,b\n1,x\n,y\n",
+			opts:  []CSVOption{WithSchema(Schema{{Name: "a", Type: TypeInt}})},
+			want:  "missing value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromCSVReader(strings.NewReader(tt.input), tt.opts...)
+			if err == nil {
+				t.Fatal("FromCSVReader: expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromStructsSchema(t *testing.T) {
+	type row struct {
+		ID    int     `rowan:"id"`
+		Score *int    `rowan:"score"`
+		Note  string  `rowan:"-"`
+		Ratio float64 `rowan:"ratio"`
+	}
+
+	five := 5
+	tbl, err := FromStructs([]row{{ID: 1, Score: &five, Ratio: 0.5}, {ID: 2}}, Schema{{Name: "id", Type: TypeFloat}})
+	if err != nil {
+		t.Fatalf("FromStructs: %v", err)
+	}
+
+	if want := []string{"id", "score", "ratio"}; !reflect.DeepEqual(tbl.Columns(), want) {
+		t.Fatalf("columns = %v, want %v", tbl.Columns(), want)
+	}
+	if got := tbl.MustCol("id").Type(); got != TypeFloat {
+		t.Errorf("id type = %v, want %v", got, TypeFloat)
+	}
+	if got := tbl.MustCol("score").Values(); got[1] != nil {
+		t.Errorf("score = %v, want a missing value for a nil pointer", got)
+	}
+
+	if _, err := FromStructs([]row{{ID: 1}}, Schema{{Name: "missing", Type: TypeInt}}); err == nil {
+		t.Error("FromStructs: expected an error for a schema column that does not exist")
+	}
+}
@@ -0,0 +1,295 @@
+package sql
+
+import (
+	"fmt"
+
+	"github.com/go-rowan/rowan/internal/expr"
+	"github.com/go-rowan/rowan/table"
+)
+
+// aggregates are the names of the aggregate functions.
+var aggregates = map[string]bool{
+	"count": true, "sum": true, "avg": true, "min": true, "max": true, "median": true, "stddev": true,
+}
+
+func isAggregate(n expr.Node) bool {
+	c, ok := n.(*expr.Call)
+	return ok && aggregates[c.Name]
+}
+
+func hasAggregate(n expr.Node) bool {
+	found := false
+	expr.Walk(n, func(n expr.Node) bool {
+		if isAggregate(n) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// aggregateCall is an aggregate function call, computed into the column name of the aggregated table.
+type aggregateCall struct {
+	call *expr.Call
+	name string
+	// arg is the column holding the argument, or empty for count(*).
+	arg string
+	fn  table.AggFunc
+}
+
+// aggregate groups t by the groupBy expressions and computes the aggregate calls found in the outputs, the HAVING condition and the ORDER BY expressions.
+//
+// It returns a table with one row per group, and rewrites the expressions to refer to its columns. A single group holds all the rows when there is no GROUP BY,
+// even if there are none.
+func aggregate(t *table.Table, groupBy []expr.Node, outputs []output, having *expr.Node, order []orderItem) (*table.Table, error) {
+	keys := make([]string, len(groupBy))
+	keyOf := make(map[string]string, len(groupBy))
+
+	for i, g := range groupBy {
+		var err error
+		if t, keys[i], err = columnOf(t, g, fmt.Sprintf("__group%d", i)); err != nil {
+			return nil, err
+		}
+		keyOf[g.String()] = keys[i]
+	}
+
+	exprs := []*expr.Node{}
+	for i := range outputs {
+		exprs = append(exprs, &outputs[i].expr)
+	}
+	if *having != nil {
+		exprs = append(exprs, having)
+	}
+	for i := range order {
+		exprs = append(exprs, &order[i].expr)
+	}
+
+	calls := []*aggregateCall{}
+	callOf := map[string]*aggregateCall{}
+
+	for _, x := range exprs {
+		var err error
+		expr.Walk(*x, func(n expr.Node) bool {
+			if err != nil || !isAggregate(n) {
+				return err == nil
+			}
+
+			key := n.String()
+			if _, ok := callOf[key]; !ok {
+				var c *aggregateCall
+				if c, t, err = newAggregateCall(t, n.(*expr.Call), len(calls)); err != nil {
+					return false
+				}
+				calls = append(calls, c)
+				callOf[key] = c
+			}
+			return false
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		result *table.Table
+		err    error
+	)
+	if len(keys) == 0 {
+		result, err = aggregateAll(t, calls)
+	} else {
+		result, err = aggregateGroups(t, keys, calls)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]bool{}
+	for _, c := range result.Columns() {
+		columns[c] = true
+	}
+
+	for _, x := range exprs {
+		*x = expr.Rewrite(*x, func(n expr.Node) (expr.Node, bool) {
+			if key, ok := keyOf[n.String()]; ok {
+				return &expr.Ident{Name: key, Position: n.Pos()}, true
+			}
+			if c, ok := callOf[n.String()]; ok {
+				return &expr.Ident{Name: c.name, Position: n.Pos()}, true
+			}
+			return nil, false
+		})
+
+		var err error
+		expr.Walk(*x, func(n expr.Node) bool {
+			if id, ok := n.(*expr.Ident); ok && !columns[id.Name] && err == nil {
+				err = fmt.Errorf("sql: column %s must appear in GROUP BY or be used in an aggregate function", id.Name)
+			}
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// newAggregateCall checks an aggregate call, and adds its argument to t when it is not a plain column reference.
+func newAggregateCall(t *table.Table, call *expr.Call, index int) (*aggregateCall, *table.Table, error) {
+	c := &aggregateCall{call: call, name: fmt.Sprintf("__agg%d", index)}
+
+	if len(call.Args) != 1 {
+		return nil, nil, fmt.Errorf("sql: %s expects 1 argument, got %d", call.Name, len(call.Args))
+	}
+	if call.Distinct && call.Name != "count" {
+		return nil, nil, fmt.Errorf("sql: DISTINCT is only supported in count")
+	}
+
+	arg := call.Args[0]
+	if hasAggregate(arg) {
+		return nil, nil, fmt.Errorf("sql: aggregate function calls can not be nested in %s", call)
+	}
+
+	if _, ok := arg.(*expr.Star); ok {
+		if call.Name != "count" || call.Distinct {
+			return nil, nil, fmt.Errorf("sql: * is only supported in count(*)")
+		}
+		c.fn = countRows
+		return c, t, nil
+	}
+
+	var err error
+	if t, c.arg, err = columnOf(t, arg, fmt.Sprintf("__arg%d", index)); err != nil {
+		return nil, nil, err
+	}
+
+	col := t.MustCol(c.arg)
+	switch call.Name {
+	case "sum", "avg", "median", "stddev":
+		switch col.Type() {
+		case table.TypeInt, table.TypeFloat, table.TypeUnknown:
+		default:
+			return nil, nil, fmt.Errorf("sql: %s requires a numeric argument, got %s", call.Name, col.Type())
+		}
+	}
+
+	switch call.Name {
+	case "count":
+		c.fn = table.AggCount
+		if call.Distinct {
+			c.fn = countDistinct
+		}
+	case "sum":
+		c.fn = aggSum
+	case "avg":
+		c.fn = table.AggMean
+	case "min":
+		c.fn = aggMin
+	case "max":
+		c.fn = aggMax
+	case "median":
+		c.fn = table.AggMedian
+	case "stddev":
+		c.fn = table.AggStd
+	}
+	return c, t, nil
+}
+
+// aggregateAll computes the aggregate calls over all the rows of t.
+func aggregateAll(t *table.Table, calls []*aggregateCall) (*table.Table, error) {
+	data := make(map[string][]any, len(calls))
+	order := make([]string, len(calls))
+
+	for i, c := range calls {
+		var v any
+		if c.arg == "" {
+			v = int64(t.Len())
+		} else {
+			v = c.fn.Fn(t.MustCol(c.arg))
+		}
+
+		data[c.name] = []any{v}
+		order[i] = c.name
+	}
+
+	return table.New(data, order)
+}
+
+// aggregateGroups computes the aggregate calls over every group of rows of t with the same keys, with GroupedTable.Agg.
+func aggregateGroups(t *table.Table, keys []string, calls []*aggregateCall) (*table.Table, error) {
+	g, err := t.GroupBy(keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(calls) == 0 {
+		return g.Size().Select(keys...)
+	}
+
+	aggs := map[string][]table.AggFunc{}
+	names := map[string]string{}
+
+	for _, c := range calls {
+		arg := c.arg
+		if arg == "" {
+			arg = keys[0]
+		}
+
+		f := table.AggFunc{Name: c.name, Fn: c.fn.Fn}
+		aggs[arg] = append(aggs[arg], f)
+		names[arg+"_"+f.Name] = c.name
+	}
+
+	result, err := g.Agg(aggs)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.RenameColumns(names); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+var (
+	countRows = table.AggFunc{Name: "count", Fn: func(c *table.Column) any {
+		return int64(c.Len())
+	}}
+
+	countDistinct = table.AggFunc{Name: "count_distinct", Fn: func(c *table.Column) any {
+		return int64(c.NUnique())
+	}}
+
+	// aggSum keeps integer sums as integers, and gives null when there are no values.
+	aggSum = table.AggFunc{Name: "sum", Fn: func(c *table.Column) any {
+		if c.Count() == 0 {
+			return nil
+		}
+		if c.Type() != table.TypeInt {
+			return table.AggSum.Fn(c)
+		}
+
+		var sum int64
+		for i := 0; i < c.Len(); i++ {
+			if v, ok := c.At(i).(int64); ok {
+				sum += v
+			}
+		}
+		return sum
+	}}
+
+	// aggMin and aggMax compare values of any type, like ORDER BY.
+	aggMin = table.AggFunc{Name: "min", Fn: func(c *table.Column) any {
+		if c.Count() == 0 {
+			return nil
+		}
+		return c.At(c.Argsort()[0])
+	}}
+
+	aggMax = table.AggFunc{Name: "max", Fn: func(c *table.Column) any {
+		n := c.Count()
+		if n == 0 {
+			return nil
+		}
+		return c.At(c.Argsort()[n-1])
+	}}
+)
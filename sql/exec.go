@@ -0,0 +1,481 @@
+package sql
+
+import (
+	"fmt"
+
+	"github.com/go-rowan/rowan/internal/expr"
+	"github.com/go-rowan/rowan/table"
+)
+
+// Inside a query, the columns of every table are renamed "<alias>.<column>", and references to them are rewritten accordingly,
+// so that tables can be joined without name collisions. Intermediate columns are named with a "__" prefix and no dot.
+
+// column is a column of a table in the FROM clause.
+type column struct {
+	table string
+	name  string
+}
+
+func (c column) qualified() string {
+	return c.table + "." + c.name
+}
+
+// scope holds the columns that references in expressions can resolve to.
+type scope []column
+
+// resolve returns the qualified name of the column referred to by id.
+func (s scope) resolve(id *expr.Ident) (string, error) {
+	match := ""
+	for _, c := range s {
+		if c.name != id.Name || (id.Qualifier != "" && c.table != id.Qualifier) {
+			continue
+		}
+		if match != "" {
+			return "", fmt.Errorf("sql: column reference %s is ambiguous", id)
+		}
+		match = c.qualified()
+	}
+
+	if match == "" {
+		return "", fmt.Errorf("sql: column %s does not exist", id)
+	}
+	return match, nil
+}
+
+// qualify rewrites the column references of n to qualified names.
+func (s scope) qualify(n expr.Node) (expr.Node, error) {
+	var err error
+	q := expr.Rewrite(n, func(n expr.Node) (expr.Node, bool) {
+		id, ok := n.(*expr.Ident)
+		if !ok {
+			return nil, false
+		}
+
+		name, resolveErr := s.resolve(id)
+		if resolveErr != nil {
+			if err == nil {
+				err = resolveErr
+			}
+			return n, true
+		}
+		return &expr.Ident{Name: name, Position: id.Position}, true
+	})
+	return q, err
+}
+
+// output is a column of the result.
+type output struct {
+	expr expr.Node
+	name string
+	// fallback is the qualified name used for a plain column reference whose name collides with another output column.
+	fallback string
+}
+
+func (e *Engine) execute(stmt *statement) (*table.Table, error) {
+	aliases := map[string]bool{}
+
+	work, s, err := e.source(stmt.from, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, j := range stmt.joins {
+		right, rs, err := e.source(j.table, aliases)
+		if err != nil {
+			return nil, err
+		}
+		if work, s, err = join(work, s, right, rs, j); err != nil {
+			return nil, err
+		}
+	}
+
+	if stmt.where != nil {
+		if hasAggregate(stmt.where) {
+			return nil, fmt.Errorf("sql: aggregate functions are not allowed in WHERE")
+		}
+		if work, err = filter(work, s, stmt.where); err != nil {
+			return nil, err
+		}
+	}
+
+	outputs, err := s.outputs(stmt.items)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderBy(stmt.orderBy, outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var having expr.Node
+	if stmt.having != nil {
+		if having, err = s.qualify(stmt.having); err != nil {
+			return nil, err
+		}
+	}
+
+	if grouped(stmt, outputs, having, order) {
+		for _, item := range stmt.items {
+			if item.star {
+				return nil, fmt.Errorf("sql: * can not be used with GROUP BY or aggregate functions")
+			}
+		}
+
+		groupBy := make([]expr.Node, len(stmt.groupBy))
+		for i, g := range stmt.groupBy {
+			if hasAggregate(g) {
+				return nil, fmt.Errorf("sql: aggregate functions are not allowed in GROUP BY")
+			}
+			if groupBy[i], err = s.qualify(g); err != nil {
+				return nil, err
+			}
+		}
+
+		if work, err = aggregate(work, groupBy, outputs, &having, order); err != nil {
+			return nil, err
+		}
+	} else if having != nil {
+		return nil, fmt.Errorf("sql: HAVING requires GROUP BY or an aggregate function")
+	}
+
+	if having != nil {
+		if work, err = filterExpr(work, having); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(order) > 0 {
+		if work, err = sortBy(work, order); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := project(work, outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.distinct {
+		if result, err = result.Distinct(); err != nil {
+			return nil, err
+		}
+	}
+
+	return limit(result, stmt.offset, stmt.limit)
+}
+
+// source returns a copy of the table referred to by ref, with its columns renamed to their qualified names.
+func (e *Engine) source(ref tableRef, aliases map[string]bool) (*table.Table, scope, error) {
+	t, ok := e.tables[ref.name]
+	if !ok {
+		return nil, nil, fmt.Errorf("sql: table %s is not registered", ref.name)
+	}
+
+	if aliases[ref.alias] {
+		return nil, nil, fmt.Errorf("sql: table name %s is used more than once, use an alias", ref.alias)
+	}
+	aliases[ref.alias] = true
+
+	cols := t.Columns()
+	s := make(scope, len(cols))
+	names := make(map[string]string, len(cols))
+	for i, c := range cols {
+		s[i] = column{table: ref.alias, name: c}
+		names[c] = s[i].qualified()
+	}
+
+	result := t.Clone()
+	if err := result.RenameColumns(names); err != nil {
+		return nil, nil, err
+	}
+	return result, s, nil
+}
+
+// join joins right to left according to j, and returns the joined table with its scope.
+//
+// The equalities between a column of each side in the ON condition are the join keys. Any remaining condition is applied as a filter, which is only correct for inner joins.
+func join(left *table.Table, ls scope, right *table.Table, rs scope, j joinClause) (*table.Table, scope, error) {
+	s := append(ls[:len(ls):len(ls)], rs...)
+
+	on, err := s.qualify(j.on)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rightColumns := map[string]bool{}
+	for _, c := range rs {
+		rightColumns[c.qualified()] = true
+	}
+
+	leftOn, rightOn := []string{}, []string{}
+	residual := []expr.Node{}
+
+	for _, cond := range conjuncts(on) {
+		if b, ok := cond.(*expr.Binary); ok && b.Op == "==" {
+			x, xOk := b.X.(*expr.Ident)
+			y, yOk := b.Y.(*expr.Ident)
+
+			if xOk && yOk && rightColumns[x.Name] != rightColumns[y.Name] {
+				if rightColumns[x.Name] {
+					x, y = y, x
+				}
+				leftOn = append(leftOn, x.Name)
+				rightOn = append(rightOn, y.Name)
+				continue
+			}
+		}
+		residual = append(residual, cond)
+	}
+
+	if len(leftOn) == 0 {
+		return nil, nil, fmt.Errorf("sql: join with %s requires an equality between columns of both tables", j.table.alias)
+	}
+	if len(residual) > 0 && j.how != table.InnerJoin {
+		return nil, nil, fmt.Errorf("sql: %s join with %s only supports equalities between columns of both tables", j.how, j.table.alias)
+	}
+
+	result, err := left.Join(right, leftOn, j.how, table.WithRightOn(rightOn...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, cond := range residual {
+		if result, err = filterExpr(result, cond); err != nil {
+			return nil, nil, err
+		}
+	}
+	return result, s, nil
+}
+
+// conjuncts splits n into the conditions combined by AND.
+func conjuncts(n expr.Node) []expr.Node {
+	if b, ok := n.(*expr.Binary); ok && b.Op == "&&" {
+		return append(conjuncts(b.X), conjuncts(b.Y)...)
+	}
+	return []expr.Node{n}
+}
+
+// outputs expands the select list into the output columns, with their expressions qualified.
+func (s scope) outputs(items []selectItem) ([]output, error) {
+	outputs := []output{}
+
+	for _, item := range items {
+		if item.star {
+			found := false
+			for _, c := range s {
+				if item.qualifier != "" && c.table != item.qualifier {
+					continue
+				}
+				found = true
+				outputs = append(outputs, output{expr: &expr.Ident{Name: c.qualified()}, name: c.name, fallback: c.qualified()})
+			}
+
+			if !found && item.qualifier != "" {
+				return nil, fmt.Errorf("sql: table %s in select list does not exist", item.qualifier)
+			}
+			continue
+		}
+
+		x, err := s.qualify(item.expr)
+		if err != nil {
+			return nil, err
+		}
+
+		o := output{expr: x, name: item.alias}
+		if o.name == "" {
+			if id, ok := item.expr.(*expr.Ident); ok {
+				o.name = id.Name
+				o.fallback = x.(*expr.Ident).Name
+			} else {
+				o.name = item.expr.String()
+			}
+		}
+		outputs = append(outputs, o)
+	}
+
+	counts := map[string]int{}
+	for _, o := range outputs {
+		counts[o.name]++
+	}
+
+	seen := map[string]bool{}
+	for i := range outputs {
+		if counts[outputs[i].name] > 1 && outputs[i].fallback != "" {
+			outputs[i].name = outputs[i].fallback
+		}
+
+		if seen[outputs[i].name] {
+			return nil, fmt.Errorf("sql: duplicate column name %s in select list", outputs[i].name)
+		}
+		seen[outputs[i].name] = true
+	}
+
+	return outputs, nil
+}
+
+// orderBy qualifies the ORDER BY expressions. An unqualified name matching an output column, or a 1-based position, refers to that output column.
+func (s scope) orderBy(items []orderItem, outputs []output) ([]orderItem, error) {
+	order := make([]orderItem, len(items))
+
+	for i, item := range items {
+		order[i] = item
+
+		if lit, ok := item.expr.(*expr.Literal); ok {
+			n, ok := lit.Value.(int64)
+			if !ok || n < 1 || int(n) > len(outputs) {
+				return nil, fmt.Errorf("sql: ORDER BY position %s is not in select list", lit)
+			}
+			order[i].expr = outputs[n-1].expr
+			continue
+		}
+
+		if id, ok := item.expr.(*expr.Ident); ok && id.Qualifier == "" {
+			if o, found := findOutput(outputs, id.Name); found {
+				order[i].expr = o.expr
+				continue
+			}
+		}
+
+		x, err := s.qualify(item.expr)
+		if err != nil {
+			return nil, err
+		}
+		order[i].expr = x
+	}
+
+	return order, nil
+}
+
+func findOutput(outputs []output, name string) (output, bool) {
+	for _, o := range outputs {
+		if o.name == name {
+			return o, true
+		}
+	}
+	return output{}, false
+}
+
+// grouped reports whether the query aggregates rows, either with GROUP BY or with aggregate functions.
+func grouped(stmt *statement, outputs []output, having expr.Node, order []orderItem) bool {
+	if len(stmt.groupBy) > 0 || (having != nil && hasAggregate(having)) {
+		return true
+	}
+	for _, o := range outputs {
+		if hasAggregate(o.expr) {
+			return true
+		}
+	}
+	for _, o := range order {
+		if hasAggregate(o.expr) {
+			return true
+		}
+	}
+	return false
+}
+
+func filter(t *table.Table, s scope, n expr.Node) (*table.Table, error) {
+	q, err := s.qualify(n)
+	if err != nil {
+		return nil, err
+	}
+	return filterExpr(t, q)
+}
+
+// filterExpr and withColumn apply a qualified expression with the table operations. The expression is converted back to source, which always parses to the same tree.
+func filterExpr(t *table.Table, n expr.Node) (*table.Table, error) {
+	e, err := table.ParseExpr(n.String())
+	if err != nil {
+		return nil, err
+	}
+	return t.FilterExpr(e)
+}
+
+func withColumn(t *table.Table, name string, n expr.Node) (*table.Table, error) {
+	e, err := table.ParseExpr(n.String())
+	if err != nil {
+		return nil, err
+	}
+	return t.WithColumnExpr(name, e)
+}
+
+// columnOf returns the name of a column holding the values of n, adding one named name when n is not a plain column reference.
+func columnOf(t *table.Table, n expr.Node, name string) (*table.Table, string, error) {
+	if id, ok := n.(*expr.Ident); ok {
+		return t, id.Name, nil
+	}
+
+	t, err := withColumn(t, name, n)
+	if err != nil {
+		return nil, "", err
+	}
+	return t, name, nil
+}
+
+func sortBy(t *table.Table, order []orderItem) (*table.Table, error) {
+	keys := make([]table.SortKey, len(order))
+
+	for i, o := range order {
+		var (
+			name string
+			err  error
+		)
+		if t, name, err = columnOf(t, o.expr, fmt.Sprintf("__order%d", i)); err != nil {
+			return nil, err
+		}
+		keys[i] = table.SortKey{Column: name, Descending: o.desc, NullsFirst: o.nullsFirst}
+	}
+
+	return t.SortBy(keys...)
+}
+
+// project returns a table holding the output columns.
+func project(t *table.Table, outputs []output) (*table.Table, error) {
+	sources := make([]string, len(outputs))
+	names := make(map[string]string, len(outputs))
+	used := map[string]bool{}
+
+	for i, o := range outputs {
+		id, ok := o.expr.(*expr.Ident)
+		if ok && !used[id.Name] {
+			sources[i] = id.Name
+		} else {
+			sources[i] = fmt.Sprintf("__col%d", i)
+
+			var err error
+			if t, err = withColumn(t, sources[i], o.expr); err != nil {
+				return nil, err
+			}
+		}
+
+		used[sources[i]] = true
+		names[sources[i]] = o.name
+	}
+
+	result, err := t.Select(sources...)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.RenameColumns(names); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// limit returns the rows of t remaining after skipping offset rows, at most n of them unless n is negative.
+func limit(t *table.Table, offset, n int) (*table.Table, error) {
+	if offset == 0 && (n < 0 || n >= t.Len()) {
+		return t, nil
+	}
+
+	end := t.Len()
+	if n >= 0 && offset+n < end {
+		end = offset + n
+	}
+
+	indexes := []int{}
+	for i := offset; i < end; i++ {
+		indexes = append(indexes, i)
+	}
+	return t.SelectRows(indexes)
+}
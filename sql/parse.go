@@ -0,0 +1,329 @@
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-rowan/rowan/internal/expr"
+	"github.com/go-rowan/rowan/table"
+)
+
+// statement is a parsed SELECT statement.
+type statement struct {
+	distinct bool
+	items    []selectItem
+	from     tableRef
+	joins    []joinClause
+	where    expr.Node
+	groupBy  []expr.Node
+	having   expr.Node
+	orderBy  []orderItem
+	limit    int // -1 when there is no LIMIT clause
+	offset   int
+}
+
+// selectItem is an entry of the select list: either a star, optionally qualified by a table, or an expression with an optional alias.
+type selectItem struct {
+	star      bool
+	qualifier string
+	expr      expr.Node
+	alias     string
+}
+
+type tableRef struct {
+	name  string
+	alias string
+}
+
+type joinClause struct {
+	how   table.JoinType
+	table tableRef
+	on    expr.Node
+}
+
+type orderItem struct {
+	expr       expr.Node
+	desc       bool
+	nullsFirst bool
+}
+
+// reserved words end a clause, so they cannot be used as bare aliases.
+var reserved = map[string]bool{
+	"select": true, "from": true, "where": true, "group": true, "by": true, "having": true, "order": true,
+	"limit": true, "offset": true, "join": true, "inner": true, "left": true, "right": true, "full": true,
+	"outer": true, "on": true, "as": true, "asc": true, "desc": true, "nulls": true, "union": true,
+}
+
+func isReserved(t expr.Token) bool {
+	return t.Kind == expr.TokenIdent && reserved[strings.ToLower(t.Text)]
+}
+
+type parser struct {
+	*expr.Parser
+}
+
+// parse parses a single SELECT statement, optionally terminated by a semicolon.
+func parse(query string) (*statement, error) {
+	ep, err := expr.NewParser(query)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+
+	p := parser{ep}
+	stmt, err := p.parseSelect()
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	return stmt, nil
+}
+
+// sqlError replaces the prefix of errors reported by the expression parser.
+func sqlError(err error) error {
+	if msg, ok := strings.CutPrefix(err.Error(), "expr: "); ok {
+		return fmt.Errorf("sql: %s", msg)
+	}
+	return err
+}
+
+func (p parser) parseSelect() (*statement, error) {
+	stmt := &statement{limit: -1}
+
+	if err := p.Expect("select"); err != nil {
+		return nil, err
+	}
+	stmt.distinct = p.Accept("distinct")
+
+	for {
+		item, err := p.parseSelectItem()
+		if err != nil {
+			return nil, err
+		}
+		stmt.items = append(stmt.items, item)
+
+		if !p.Accept(",") {
+			break
+		}
+	}
+
+	if err := p.Expect("from"); err != nil {
+		return nil, err
+	}
+	from, err := p.parseTableRef()
+	if err != nil {
+		return nil, err
+	}
+	stmt.from = from
+
+	for {
+		how, ok, err := p.parseJoinType()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+
+		ref, err := p.parseTableRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.Expect("on"); err != nil {
+			return nil, err
+		}
+		on, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		stmt.joins = append(stmt.joins, joinClause{how: how, table: ref, on: on})
+	}
+
+	if p.Accept("where") {
+		if stmt.where, err = p.ParseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Accept("group") {
+		if err := p.Expect("by"); err != nil {
+			return nil, err
+		}
+		if stmt.groupBy, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Accept("having") {
+		if stmt.having, err = p.ParseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Accept("order") {
+		if err := p.Expect("by"); err != nil {
+			return nil, err
+		}
+		if stmt.orderBy, err = p.parseOrderBy(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Accept("limit") {
+		if stmt.limit, err = p.parseCount("LIMIT"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Accept("offset") {
+		if stmt.offset, err = p.parseCount("OFFSET"); err != nil {
+			return nil, err
+		}
+	}
+
+	p.Accept(";")
+	if t := p.Peek(); t.Kind != expr.TokenEOF {
+		return nil, p.Errorf("unexpected %q", t.Text)
+	}
+	return stmt, nil
+}
+
+func (p parser) parseSelectItem() (selectItem, error) {
+	if p.Accept("*") {
+		return selectItem{star: true}, nil
+	}
+
+	t := p.Peek()
+	if (t.Kind == expr.TokenIdent || t.Kind == expr.TokenQuotedIdent) && p.PeekAt(1).Is(".") && p.PeekAt(2).Is("*") {
+		p.Next()
+		p.Next()
+		p.Next()
+		return selectItem{star: true, qualifier: t.Text}, nil
+	}
+
+	x, err := p.ParseExpr()
+	if err != nil {
+		return selectItem{}, err
+	}
+
+	alias, err := p.parseAlias()
+	if err != nil {
+		return selectItem{}, err
+	}
+	return selectItem{expr: x, alias: alias}, nil
+}
+
+// parseAlias parses an optional alias, introduced by AS or given directly after the aliased item.
+func (p parser) parseAlias() (string, error) {
+	explicit := p.Accept("as")
+
+	t := p.Peek()
+	switch {
+	case t.Kind == expr.TokenQuotedIdent, t.Kind == expr.TokenIdent && !isReserved(t):
+		p.Next()
+		return t.Text, nil
+	case explicit:
+		return "", p.Errorf("expected alias after AS")
+	default:
+		return "", nil
+	}
+}
+
+func (p parser) parseTableRef() (tableRef, error) {
+	t := p.Next()
+	if (t.Kind != expr.TokenIdent && t.Kind != expr.TokenQuotedIdent) || isReserved(t) {
+		return tableRef{}, fmt.Errorf("sql: expected table name at position %d", t.Pos)
+	}
+
+	alias, err := p.parseAlias()
+	if err != nil {
+		return tableRef{}, err
+	}
+	if alias == "" {
+		alias = t.Text
+	}
+	return tableRef{name: t.Text, alias: alias}, nil
+}
+
+// parseJoinType parses the keywords introducing a join, and reports whether there was one.
+func (p parser) parseJoinType() (table.JoinType, bool, error) {
+	how := table.InnerJoin
+
+	switch {
+	case p.Accept("join"):
+		return how, true, nil
+	case p.Accept("inner"):
+	case p.Accept("left"):
+		how = table.LeftJoin
+		p.Accept("outer")
+	case p.Accept("right"):
+		how = table.RightJoin
+		p.Accept("outer")
+	case p.Accept("full"):
+		how = table.OuterJoin
+		p.Accept("outer")
+	default:
+		return how, false, nil
+	}
+
+	if err := p.Expect("join"); err != nil {
+		return how, false, err
+	}
+	return how, true, nil
+}
+
+func (p parser) parseExprList() ([]expr.Node, error) {
+	list := []expr.Node{}
+	for {
+		x, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, x)
+
+		if !p.Accept(",") {
+			return list, nil
+		}
+	}
+}
+
+func (p parser) parseOrderBy() ([]orderItem, error) {
+	items := []orderItem{}
+	for {
+		x, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		item := orderItem{expr: x}
+
+		if p.Accept("desc") {
+			item.desc = true
+		} else {
+			p.Accept("asc")
+		}
+
+		if p.Accept("nulls") {
+			switch {
+			case p.Accept("first"):
+				item.nullsFirst = true
+			case p.Accept("last"):
+			default:
+				return nil, p.Errorf("expected FIRST or LAST after NULLS")
+			}
+		}
+		items = append(items, item)
+
+		if !p.Accept(",") {
+			return items, nil
+		}
+	}
+}
+
+// parseCount parses the non-negative integer following LIMIT or OFFSET.
+func (p parser) parseCount(clause string) (int, error) {
+	t := p.Next()
+	n, err := strconv.Atoi(t.Text)
+	if t.Kind != expr.TokenNumber || err != nil || n < 0 {
+		return 0, fmt.Errorf("sql: %s must be a non-negative integer at position %d", clause, t.Pos)
+	}
+	return n, nil
+}
@@ -0,0 +1,104 @@
+// Package sql runs SQL queries against in-memory tables.
+//
+// Tables are registered under a name in an Engine, then queried with SELECT statements:
+//
+//	engine := sql.NewEngine()
+//	engine.Register("orders", orders)
+//	engine.Register("customers", customers)
+//
+//	result, err := engine.Query(`
+//		SELECT c.country, count(*) AS orders, sum(o.amount) AS total
+//		FROM orders o
+//		JOIN customers c ON o.customer_id = c.id
+//		WHERE o.status = 'paid'
+//		GROUP BY c.country
+//		HAVING sum(o.amount) > 1000
+//		ORDER BY total DESC
+//		LIMIT 10`)
+//
+// Queries are executed in-process with the operations of the table package: joins with Table.Join, WHERE and HAVING with Table.FilterExpr,
+// grouping with Table.GroupBy, sorting with Table.SortBy and SELECT DISTINCT with Table.Distinct. No database server is involved.
+//
+// The supported grammar is:
+//
+//	SELECT [DISTINCT] item [, item ...]
+//	FROM table [[AS] alias]
+//	[[INNER | LEFT [OUTER] | RIGHT [OUTER] | FULL [OUTER]] JOIN table [[AS] alias] ON condition ...]
+//	[WHERE condition]
+//	[GROUP BY expression [, expression ...]]
+//	[HAVING condition]
+//	[ORDER BY expression [ASC | DESC] [NULLS FIRST | NULLS LAST] [, ...]]
+//	[LIMIT count] [OFFSET count]
+//
+// A select item is *, table.*, or an expression with an optional alias. Expressions are those of table.Expr, where string literals may use single or double quotes
+// and names that are not plain words are enclosed in backticks. Columns may be qualified by the table name or alias, and must be when the name is ambiguous.
+//
+// The aggregate functions are count(*), count(x), count(distinct x), sum, avg, min, max, median and stddev. Aggregates ignore missing values and give null
+// when there are none, except count which gives 0. ORDER BY may refer to output columns by alias or by 1-based position.
+//
+// JOIN conditions must include at least one equality between a column of each side. Other conditions are only allowed for inner joins.
+// Missing keys never match, as in SQL.
+package sql
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-rowan/rowan/table"
+)
+
+// Engine holds named tables and executes queries against them.
+//
+// Registered tables are not modified by queries. An Engine is not safe for concurrent use while tables are being registered.
+type Engine struct {
+	tables map[string]*table.Table
+}
+
+// NewEngine returns an Engine with no tables registered.
+func NewEngine() *Engine {
+	return &Engine{tables: make(map[string]*table.Table)}
+}
+
+// Register makes t available to queries under the given name, replacing any table previously registered with that name.
+//
+// Names are case-sensitive. An error is returned if the name is empty or t is nil.
+func (e *Engine) Register(name string, t *table.Table) error {
+	if name == "" {
+		return fmt.Errorf("sql: table name can not be empty")
+	}
+	if t == nil {
+		return fmt.Errorf("sql: table %s is nil", name)
+	}
+
+	e.tables[name] = t
+	return nil
+}
+
+// Deregister removes the table registered under the given name. It is a no-op if there is none.
+func (e *Engine) Deregister(name string) {
+	delete(e.tables, name)
+}
+
+// Tables returns the names of the registered tables in sorted order.
+func (e *Engine) Tables() []string {
+	names := make([]string, 0, len(e.tables))
+	for name := range e.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Query parses and executes a SELECT statement, and returns its result as a new Table.
+//
+// Output columns are named after their alias, or after the column for plain column references, or else after the expression, for example "count(*)".
+// When plain column references from different tables share a name, they are named "<table>.<column>" instead.
+//
+// An error is returned if the statement is invalid, refers to a table or column that does not exist, or fails to evaluate.
+func (e *Engine) Query(query string) (*table.Table, error) {
+	stmt, err := parse(query)
+	if err != nil {
+		return nil, err
+	}
+	return e.execute(stmt)
+}
@@ -0,0 +1,199 @@
+package sql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-rowan/rowan/table"
+)
+
+func testEngine(tb testing.TB) *Engine {
+	tb.Helper()
+
+	orders, err := table.New(map[string][]any{
+		"id":          {int64(1), int64(2), int64(3), int64(4), int64(5)},
+		"customer_id": {int64(1), int64(2), int64(1), int64(3), nil},
+		"amount":      {10.0, 20.0, 30.0, 40.0, 50.0},
+		"status":      {"paid", "paid", "open", "paid", "paid"},
+	}, []string{"id", "customer_id", "amount", "status"})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	customers, err := table.New(map[string][]any{
+		"id":      {int64(1), int64(2), int64(3), int64(4)},
+		"name":    {"Ann", "Bob", "Cid", "Dee"},
+		"country": {"NO", "ID", "NO", "SE"},
+	}, []string{"id", "name", "country"})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	e := NewEngine()
+	if err := e.Register("orders", orders); err != nil {
+		tb.Fatal(err)
+	}
+	if err := e.Register("customers", customers); err != nil {
+		tb.Fatal(err)
+	}
+	return e
+}
+
+// assertTable checks the columns of t and their values, given column by column.
+func assertTable(tb testing.TB, t *table.Table, columns []string, values ...[]any) {
+	tb.Helper()
+
+	if got := t.Columns(); !reflect.DeepEqual(got, columns) {
+		tb.Fatalf("columns = %v, want %v", got, columns)
+	}
+	for i, c := range columns {
+		col, err := t.Col(c)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if got := col.Values(); !reflect.DeepEqual(got, values[i]) {
+			tb.Errorf("column %s = %#v, want %#v", c, got, values[i])
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	e := testEngine(t)
+
+	tests := []struct {
+		name    string
+		query   string
+		columns []string
+		values  [][]any
+	}{
+		{
+			name:    "projection and filter",
+			query:   "SELECT id, amount * 2 AS double FROM orders WHERE status = 'paid' AND amount > 15",
+			columns: []string{"id", "double"},
+			values:  [][]any{{int64(2), int64(4), int64(5)}, {40.0, 80.0, 100.0}},
+		},
+		{
+			name:    "star with order and limit",
+			query:   "SELECT * FROM customers ORDER BY name DESC LIMIT 2 OFFSET 1",
+			columns: []string{"id", "name", "country"},
+			values:  [][]any{{int64(3), int64(2)}, {"Cid", "Bob"}, {"NO", "ID"}},
+		},
+		{
+			name:    "expression name",
+			query:   "SELECT upper(name) FROM customers WHERE id = 1",
+			columns: []string{"upper(name)"},
+			values:  [][]any{{"ANN"}},
+		},
+		{
+			name:    "distinct",
+			query:   "SELECT DISTINCT country FROM customers ORDER BY country",
+			columns: []string{"country"},
+			values:  [][]any{{"ID", "NO", "SE"}},
+		},
+		{
+			name:    "inner join",
+			query:   "SELECT o.id, c.name FROM orders o JOIN customers c ON o.customer_id = c.id WHERE o.amount >= 20 ORDER BY o.id",
+			columns: []string{"id", "name"},
+			values:  [][]any{{int64(2), int64(3), int64(4)}, {"Bob", "Ann", "Cid"}},
+		},
+		{
+			name:    "left join",
+			query:   "SELECT c.name, o.id AS order_id FROM customers c LEFT JOIN orders o ON c.id = o.customer_id ORDER BY c.name, order_id",
+			columns: []string{"name", "order_id"},
+			values:  [][]any{{"Ann", "Ann", "Bob", "Cid", "Dee"}, {int64(1), int64(3), int64(2), int64(4), nil}},
+		},
+		{
+			name:    "ambiguous names are qualified",
+			query:   "SELECT o.id, c.id FROM orders o JOIN customers c ON o.customer_id = c.id ORDER BY 1 LIMIT 1",
+			columns: []string{"o.id", "c.id"},
+			values:  [][]any{{int64(1)}, {int64(1)}},
+		},
+		{
+			name:    "aggregates without group by",
+			query:   "SELECT count(*), count(customer_id), count(DISTINCT customer_id), sum(amount), avg(amount), min(amount), max(amount) FROM orders",
+			columns: []string{"count(*)", "count(customer_id)", "count(distinct customer_id)", "sum(amount)", "avg(amount)", "min(amount)", "max(amount)"},
+			values:  [][]any{{int64(5)}, {int64(4)}, {int64(3)}, {150.0}, {30.0}, {10.0}, {50.0}},
+		},
+		{
+			name: "group by with having",
+			query: `SELECT c.country, count(*) AS orders, sum(o.amount) AS total
+				FROM orders o JOIN customers c ON o.customer_id = c.id
+				WHERE o.status = 'paid'
+				GROUP BY c.country
+				HAVING sum(o.amount) > 25
+				ORDER BY total DESC`,
+			columns: []string{"country", "orders", "total"},
+			values:  [][]any{{"NO"}, {int64(2)}, {50.0}},
+		},
+		{
+			name:    "aggregate of empty input",
+			query:   "SELECT count(*) AS n, sum(amount) AS total FROM orders WHERE amount > 100",
+			columns: []string{"n", "total"},
+			values:  [][]any{{int64(0)}, {nil}},
+		},
+		{
+			name:    "nulls first",
+			query:   "SELECT customer_id FROM orders ORDER BY customer_id DESC NULLS FIRST",
+			columns: []string{"customer_id"},
+			values:  [][]any{{nil, int64(3), int64(2), int64(1), int64(1)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := e.Query(tt.query)
+			if err != nil {
+				t.Fatalf("Query: %v", err)
+			}
+			assertTable(t, result, tt.columns, tt.values...)
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	e := testEngine(t)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT", "sql:"},
+		{"UPDATE orders SET id = 1", "sql:"},
+		{"SELECT * FROM missing", "missing"},
+		{"SELECT nope FROM orders", "nope"},
+		{"SELECT id FROM orders o JOIN customers c ON o.customer_id = c.id", "ambiguous"},
+		{"SELECT * FROM orders WHERE status > 1", "cannot compare string and int"},
+		{"SELECT * FROM orders LIMIT -1", "sql:"},
+		{"SELECT * FROM orders ORDER BY 9", "9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := e.Query(tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	e := testEngine(t)
+
+	if err := e.Register("", nil); err == nil {
+		t.Error("Register with an empty name succeeded")
+	}
+	if err := e.Register("x", nil); err == nil {
+		t.Error("Register with a nil table succeeded")
+	}
+
+	if got, want := e.Tables(), []string{"customers", "orders"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tables = %v, want %v", got, want)
+	}
+
+	e.Deregister("orders")
+	if _, err := e.Query("SELECT * FROM orders"); err == nil {
+		t.Error("query on a deregistered table succeeded")
+	}
+}
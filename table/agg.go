@@ -0,0 +1,78 @@
+package table
+
+import "strconv"
+
+// AggFunc is a named aggregation that reduces a column to a single value.
+//
+// Fn receives the values of one group as a Column and returns the aggregated value, or nil if it cannot be computed.
+// Name is used to build the name of the output column, "<column>_<name>". Custom aggregations are created with a struct literal:
+//
+//	span := table.AggFunc{Name: "span", Fn: func(c *table.Column) any {
+//		min, _ := c.Min()
+//		max, ok := c.Max()
+//		if !ok {
+//			return nil
+//		}
+//		return max - min
+//	}}
+type AggFunc struct {
+	Name string
+	Fn   func(c *Column) any
+}
+
+var (
+	// AggSum is the sum of the numeric values, see Column.Sum.
+	AggSum = AggFunc{Name: "sum", Fn: floatAgg((*Column).Sum)}
+	// AggMean is the mean of the numeric values, see Column.Mean.
+	AggMean = AggFunc{Name: "mean", Fn: floatAgg((*Column).Mean)}
+	// AggMin is the minimum numeric value, see Column.Min.
+	AggMin = AggFunc{Name: "min", Fn: floatAgg((*Column).Min)}
+	// AggMax is the maximum numeric value, see Column.Max.
+	AggMax = AggFunc{Name: "max", Fn: floatAgg((*Column).Max)}
+	// AggStd is the sample standard deviation of the numeric values, see Column.Std.
+	AggStd = AggFunc{Name: "std", Fn: floatAgg((*Column).Std)}
+	// AggMedian is the median of the numeric values, see Column.Median.
+	AggMedian = AggFunc{Name: "median", Fn: floatAgg((*Column).Median)}
+	// AggCount is the number of non-missing values, see Column.Count.
+	AggCount = AggFunc{Name: "count", Fn: func(c *Column) any { return int64(c.Count()) }}
+	// AggFirst is the first non-missing value.
+	AggFirst = AggFunc{Name: "first", Fn: func(c *Column) any {
+		for i := 0; i < c.Len(); i++ {
+			if !c.IsNull(i) {
+				return c.At(i)
+			}
+		}
+		return nil
+	}}
+	// AggLast is the last non-missing value.
+	AggLast = AggFunc{Name: "last", Fn: func(c *Column) any {
+		for i := c.Len() - 1; i >= 0; i-- {
+			if !c.IsNull(i) {
+				return c.At(i)
+			}
+		}
+		return nil
+	}}
+)
+
+// AggQuantile returns an aggregation computing the q-th quantile of the numeric values, see Column.Quantile.
+//
+// The output column is named "<column>_q<q>", for example "price_q0.9".
+func AggQuantile(q float64) AggFunc {
+	return AggFunc{
+		Name: "q" + strconv.FormatFloat(q, 'g', -1, 64),
+		Fn: floatAgg(func(c *Column) (float64, bool) {
+			return c.Quantile(q)
+		}),
+	}
+}
+
+func floatAgg(f func(*Column) (float64, bool)) func(*Column) any {
+	return func(c *Column) any {
+		x, ok := f(c)
+		if !ok {
+			return nil
+		}
+		return x
+	}
+}
@@ -0,0 +1,199 @@
+package table
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/go-rowan/rowan/internal/arrowio"
+	"github.com/go-rowan/rowan/internal/numeric"
+)
+
+// arrowTimestamp is the Arrow type of time columns.
+var arrowTimestamp = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+
+// ToArrow converts the Table into an Arrow record batch, with one nullable field per column in the order of the columns.
+//
+// Integer, floating point, boolean, string and time columns are converted to Int64, Float64, Boolean, String and Timestamp(ns, UTC) arrays.
+// Columns of mixed types hold Float64 values when all their values are numbers, Binary values when they are all []byte, and strings otherwise, formatted like WriteCSV does.
+// Columns with only missing values are converted to Null arrays.
+//
+// The data is copied into memory managed by Arrow, and the caller must call Release on the record once done with it.
+func (t *Table) ToArrow() (arrow.RecordBatch, error) {
+	return t.arrowRecord(memory.DefaultAllocator)
+}
+
+// FromArrow creates a Table from one or more Arrow record batches sharing the same schema, whose rows are concatenated.
+//
+// Columns are named after the fields of the schema. Integer arrays become int64 columns, floating point and decimal arrays float64 columns,
+// boolean arrays bool columns, string arrays string columns, binary arrays []byte values, and timestamp and date arrays time.Time columns.
+// Dictionary arrays are decoded. Null values become missing values.
+//
+// The values are copied, so the records can be released once FromArrow returns.
+func FromArrow(records ...arrow.RecordBatch) (*Table, error) {
+	data, columns, err := arrowio.FromRecords(records)
+	if err != nil {
+		return nil, err
+	}
+	return New(data, columns)
+}
+
+// arrowRecord converts the Table into an Arrow record batch allocated with mem, as described by ToArrow. The caller must release the record.
+func (t *Table) arrowRecord(mem memory.Allocator) (arrow.RecordBatch, error) {
+	fields := make([]arrow.Field, len(t.columns))
+	cols := make([]arrow.Array, 0, len(t.columns))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for j, c := range t.columns {
+		col, err := arrowArray(mem, t.data[c])
+		if err != nil {
+			return nil, fmt.Errorf("arrow: column %s: %w", c, err)
+		}
+		cols = append(cols, col)
+		fields[j] = arrow.Field{Name: c, Type: col.DataType(), Nullable: true}
+	}
+
+	return array.NewRecordBatch(arrow.NewSchema(fields, nil), cols, int64(t.length)), nil
+}
+
+func arrowArray(mem memory.Allocator, v vector) (arrow.Array, error) {
+	switch vec := v.(type) {
+	case *typedVector[int64]:
+		b := array.NewInt64Builder(mem)
+		defer b.Release()
+		b.AppendValues(vec.values, validity(vec.valid, len(vec.values)))
+		return b.NewArray(), nil
+
+	case *typedVector[int]:
+		values := make([]int64, len(vec.values))
+		for i, x := range vec.values {
+			values[i] = int64(x)
+		}
+
+		b := array.NewInt64Builder(mem)
+		defer b.Release()
+		b.AppendValues(values, validity(vec.valid, len(vec.values)))
+		return b.NewArray(), nil
+
+	case *typedVector[float64]:
+		b := array.NewFloat64Builder(mem)
+		defer b.Release()
+		b.AppendValues(vec.values, validity(vec.valid, len(vec.values)))
+		return b.NewArray(), nil
+
+	case *typedVector[bool]:
+		b := array.NewBooleanBuilder(mem)
+		defer b.Release()
+		b.AppendValues(vec.values, validity(vec.valid, len(vec.values)))
+		return b.NewArray(), nil
+
+	case *typedVector[string]:
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues(vec.values, validity(vec.valid, len(vec.values)))
+		return b.NewArray(), nil
+
+	case *typedVector[time.Time]:
+		b := array.NewTimestampBuilder(mem, arrowTimestamp)
+		defer b.Release()
+
+		for i, x := range vec.values {
+			if !vec.valid.get(i) {
+				b.AppendNull()
+				continue
+			}
+
+			ts, err := arrow.TimestampFromTime(x, arrowTimestamp.Unit)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", i, err)
+			}
+			b.Append(ts)
+		}
+		return b.NewArray(), nil
+
+	default:
+		return arrowMixedArray(mem, v), nil
+	}
+}
+
+// arrowMixedArray converts a column whose values do not share a single type.
+func arrowMixedArray(mem memory.Allocator, v vector) arrow.Array {
+	n := v.Len()
+	numbers, bytes, present := true, true, false
+
+	for i := 0; i < n; i++ {
+		if v.IsNull(i) {
+			continue
+		}
+		present = true
+
+		x := v.At(i)
+		if _, ok := numeric.ToFloat64(x); !ok {
+			numbers = false
+		}
+		if _, ok := x.([]byte); !ok {
+			bytes = false
+		}
+	}
+
+	switch {
+	case !present:
+		return array.NewNull(n)
+
+	case numbers:
+		b := array.NewFloat64Builder(mem)
+		defer b.Release()
+		for i := 0; i < n; i++ {
+			if v.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			f, _ := numeric.ToFloat64(v.At(i))
+			b.Append(f)
+		}
+		return b.NewArray()
+
+	case bytes:
+		b := array.NewBinaryBuilder(mem, arrow.BinaryTypes.Binary)
+		defer b.Release()
+		for i := 0; i < n; i++ {
+			if v.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(v.At(i).([]byte))
+		}
+		return b.NewArray()
+
+	default:
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		for i := 0; i < n; i++ {
+			if v.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(csvValue(v.At(i)))
+		}
+		return b.NewArray()
+	}
+}
+
+// validity returns the validity of the first n values as expected by Arrow builders, or nil when all values are present.
+func validity(valid bitmap, n int) []bool {
+	if valid == nil {
+		return nil
+	}
+
+	result := make([]bool, n)
+	for i := range result {
+		result[i] = valid.get(i)
+	}
+	return result
+}
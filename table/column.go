@@ -5,12 +5,26 @@ import "fmt"
 // Column represents a single column in a table.
 //
 // A column holds its name, underlying data, and metadata inferred from its values (such as whether it should be treated as categorical).
+// The data is stored in a typed vector whenever all values share the same type, see Type.
 type Column struct {
 	name        string
-	data        []any
+	data        vector
 	categorical bool
 }
 
+// NewColumn creates a standalone Column with the given name and values.
+//
+// The values are copied into typed storage when they all share the same type.
+func NewColumn(name string, values []any) *Column {
+	col := &Column{
+		name: name,
+		data: newVector(values),
+	}
+
+	col.categorical = inferCategorical(col.data, 3)
+	return col
+}
+
 // Name returns the name of the column.
 func (c *Column) Name() string {
 	return c.name
@@ -20,26 +34,41 @@ func (c *Column) Name() string {
 //
 // Modifying the returned slice does not affect the original column data.
 func (c *Column) Values() []any {
-	vals := make([]any, len(c.data))
-	copy(vals, c.data)
-	return vals
+	return c.data.Values()
+}
+
+// Len returns the number of values in the column, including missing ones.
+func (c *Column) Len() int {
+	return c.data.Len()
+}
+
+// At returns the value at row index i, or nil if the value is missing.
+//
+// At panics if i is out of range.
+func (c *Column) At(i int) any {
+	return c.data.At(i)
+}
+
+// Type returns the type of the column values.
+//
+// TypeUnknown is returned for columns mixing several types or holding values of other Go types.
+func (c *Column) Type() Type {
+	return c.data.Type()
 }
 
 // Col returns a column by name.
 //
-// The returned column contains a copy of the underlying data, so changes to the column do not mutate the original table. An error is returned if the column does not exist.
+// The returned column shares the underlying data with the table rather than copying it. Column data is never modified in place: Column methods return new columns, and Values returns a copy,
+// so changes made through the column do not mutate the original table. An error is returned if the column does not exist.
 func (t *Table) Col(name string) (*Column, error) {
 	originData, ok := t.data[name]
 	if !ok {
 		return nil, fmt.Errorf("column %s not found", name)
 	}
 
-	data := make([]any, len(originData))
-	copy(data, originData)
-
 	col := &Column{
 		name: name,
-		data: data,
+		data: originData,
 	}
 
 	col.categorical = inferCategorical(col.data, 3)
@@ -52,7 +81,7 @@ func (t *Table) Col(name string) (*Column, error) {
 func (t *Table) MustCol(name string) *Column {
 	col, err := t.Col(name)
 	if err != nil {
-		return &Column{name: name, data: []any{}}
+		return &Column{name: name, data: &anyVector{values: []any{}}}
 	}
 	return col
 }
@@ -1,36 +1,46 @@
 package table
 
-func inferCategorical(data []any, maxUnique int) bool {
-	if len(data) == 0 {
-		return false
+import "reflect"
+
+func inferCategorical(data vector, maxUnique int) bool {
+	var first any
+	for i := 0; i < data.Len() && first == nil; i++ {
+		first = data.At(i)
 	}
 
-	switch data[0].(type) {
-	case bool:
+	switch first.(type) {
+	case string, int, int64:
+	default:
 		return false
 	}
 
 	uniques := make(map[any]struct{})
-	for _, d := range data {
-		uniques[d] = struct{}{}
+	for i := 0; i < data.Len(); i++ {
+		if data.IsNull(i) {
+			continue
+		}
+
+		v := data.At(i)
+		if !reflect.TypeOf(v).Comparable() {
+			// values such as maps and slices can not be counted
+			return false
+		}
+
+		uniques[v] = struct{}{}
 		if len(uniques) > maxUnique {
 			return false
 		}
 	}
 
-	switch data[0].(type) {
-	case string, int, int64:
-		return true
-	default:
-		return false
-	}
+	return true
 }
 
 func isNumericColumn(c *Column) bool {
-	for _, v := range c.data {
-		if _, ok := asNumeric(v); ok {
-			return true
-		}
-	}
-	return false
+	return len(numericValues(c.data)) > 0
+}
+
+// isNumeric reports whether the column is stored as a typed numeric vector.
+func (c *Column) isNumeric() bool {
+	t := c.data.Type()
+	return t == TypeInt || t == TypeFloat
 }
@@ -0,0 +1,243 @@
+package table
+
+import "fmt"
+
+// FillStrategy selects how FillNAStrategy computes the value used to replace missing values.
+type FillStrategy int
+
+const (
+	// FillMean replaces missing values with the mean of the numeric values in the column.
+	FillMean FillStrategy = iota
+	// FillMedian replaces missing values with the median of the numeric values in the column.
+	FillMedian
+	// FillMode replaces missing values with the most frequent value in the column. Ties are broken by the value seen first.
+	FillMode
+)
+
+// String returns the name of the strategy.
+func (s FillStrategy) String() string {
+	switch s {
+	case FillMean:
+		return "mean"
+	case FillMedian:
+		return "median"
+	case FillMode:
+		return "mode"
+	default:
+		return fmt.Sprintf("FillStrategy(%d)", int(s))
+	}
+}
+
+// FillNA returns a new Column where every missing value is replaced with value. The original Column remains unchanged.
+//
+// Integer values are converted to the numeric type of the column, so filling an int64 column with 0 keeps it typed. Filling an integer column with a float64 promotes the whole column to float64.
+func (c *Column) FillNA(value any) *Column {
+	if value == nil {
+		return &Column{name: c.name, data: c.data}
+	}
+
+	col := c
+	if _, ok := value.(float64); ok && c.data.Type() == TypeInt {
+		col = c.MapFloat(func(x float64) float64 { return x })
+	}
+
+	value = fillValue(col.data, value)
+
+	values := col.data.Values()
+	for i, v := range values {
+		if v == nil {
+			values[i] = value
+		}
+	}
+
+	return &Column{
+		name: c.name,
+		data: newVector(values),
+	}
+}
+
+// FillNAStrategy returns a new Column where every missing value is replaced with a value computed from the column itself. The original Column remains unchanged.
+//
+// FillMean and FillMedian require a numeric column, an error is returned otherwise. If the column has no values to compute the fill value from, a copy of the column is returned.
+func (c *Column) FillNAStrategy(strategy FillStrategy) (*Column, error) {
+	var (
+		value any
+		ok    bool
+	)
+
+	switch strategy {
+	case FillMean, FillMedian:
+		if !isNumericColumn(c) && c.Count() > 0 {
+			return nil, fmt.Errorf("fill na: column %s is not numeric", c.name)
+		}
+
+		var x float64
+		if strategy == FillMean {
+			x, ok = c.Mean()
+		} else {
+			x, ok = c.Median()
+		}
+		value = x
+	case FillMode:
+		value, ok = c.mode()
+	default:
+		return nil, fmt.Errorf("fill na: unknown strategy %s", strategy)
+	}
+
+	if !ok {
+		return &Column{name: c.name, data: c.data}, nil
+	}
+
+	return c.FillNA(value), nil
+}
+
+// FFill returns a new Column where every missing value is replaced with the last non-missing value before it. Missing values at the start of the column stay missing.
+//
+// The storage type of the column is preserved. The original Column remains unchanged.
+func (c *Column) FFill() *Column {
+	n := c.data.Len()
+	indexes := make([]int, n)
+
+	last := -1
+	for i := 0; i < n; i++ {
+		if !c.data.IsNull(i) {
+			last = i
+		}
+
+		indexes[i] = i
+		if last >= 0 {
+			indexes[i] = last
+		}
+	}
+
+	return &Column{
+		name: c.name,
+		data: c.data.Take(indexes),
+	}
+}
+
+// BFill returns a new Column where every missing value is replaced with the next non-missing value after it. Missing values at the end of the column stay missing.
+//
+// The storage type of the column is preserved. The original Column remains unchanged.
+func (c *Column) BFill() *Column {
+	n := c.data.Len()
+	indexes := make([]int, n)
+
+	next := -1
+	for i := n - 1; i >= 0; i-- {
+		if !c.data.IsNull(i) {
+			next = i
+		}
+
+		indexes[i] = i
+		if next >= 0 {
+			indexes[i] = next
+		}
+	}
+
+	return &Column{
+		name: c.name,
+		data: c.data.Take(indexes),
+	}
+}
+
+// Interpolate returns a new Column where missing values between two numeric values are filled by linear interpolation over the row positions.
+//
+// The result is stored as float64. Missing values before the first or after the last numeric value stay missing.
+// Only numeric columns are supported, an error is returned if the column holds non-numeric values.
+func (c *Column) Interpolate() (*Column, error) {
+	n := c.data.Len()
+	result := &typedVector[float64]{
+		typ:    TypeFloat,
+		values: make([]float64, n),
+	}
+
+	prev := -1
+	for i := 0; i < n; i++ {
+		if c.data.IsNull(i) {
+			continue
+		}
+
+		x, ok := asNumeric(c.data.At(i))
+		if !ok {
+			return nil, fmt.Errorf("interpolate: column %s is not numeric", c.name)
+		}
+		result.values[i] = x
+
+		if prev < 0 {
+			for j := 0; j < i; j++ {
+				result.valid.clear(j, n)
+			}
+		} else {
+			step := (x - result.values[prev]) / float64(i-prev)
+			for j := prev + 1; j < i; j++ {
+				result.values[j] = result.values[prev] + step*float64(j-prev)
+			}
+		}
+		prev = i
+	}
+
+	for j := prev + 1; j < n; j++ {
+		result.valid.clear(j, n)
+	}
+
+	return &Column{
+		name: c.name,
+		data: result,
+	}, nil
+}
+
+// mode returns the most frequent non-missing value of the column. Ties are broken by the value seen first.
+//
+// Values are counted by their row key, so numbers are compared by value like GroupBy does, and values that can not be map keys, such as maps and slices, are counted too.
+func (c *Column) mode() (any, bool) {
+	counts := make(map[string]int)
+	keys := []string{}
+	values := []any{}
+
+	for i := 0; i < c.data.Len(); i++ {
+		v := c.data.At(i)
+		if v == nil {
+			continue
+		}
+
+		key := string(appendKey(nil, v))
+		if _, ok := counts[key]; !ok {
+			keys = append(keys, key)
+			values = append(values, v)
+		}
+		counts[key]++
+	}
+
+	var (
+		best      any
+		bestCount int
+	)
+	for i, key := range keys {
+		if counts[key] > bestCount {
+			best = values[i]
+			bestCount = counts[key]
+		}
+	}
+
+	return best, bestCount > 0
+}
+
+// fillValue converts a numeric fill value to the storage type of v, so that filling does not turn a typed column into a mixed one.
+func fillValue(v vector, value any) any {
+	switch v.(type) {
+	case *typedVector[int64]:
+		if n, ok := value.(int); ok {
+			return int64(n)
+		}
+	case *typedVector[int]:
+		if n, ok := value.(int64); ok {
+			return int(n)
+		}
+	case *typedVector[float64]:
+		if x, ok := asNumeric(value); ok {
+			return x
+		}
+	}
+	return value
+}
@@ -1,10 +1,12 @@
 package table
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/go-rowan/rowan/internal/numeric"
+	"github.com/go-rowan/rowan/internal/parallel"
 )
 
 // Map applies the provided function `f` to each value in the Column and returns a new Column containing the results. The original Column remains unchanged.
@@ -15,17 +17,65 @@ import (
 // Returns:
 //   - *Column: a new Column with the mapped values.
 func (c *Column) Map(f func(any) any) *Column {
-	values := make([]any, len(c.data))
-	for i, v := range c.data {
-		values[i] = f(v)
+	values := make([]any, c.data.Len())
+	for i := range values {
+		values[i] = f(c.data.At(i))
 	}
 
 	return &Column{
 		name: c.name,
-		data: values,
+		data: newVector(values),
 	}
 }
 
+// MapFloat applies the provided function `f` to each numeric value in the Column and returns a new Column containing the results as float64. Non-numeric and missing values are kept as-is. The original Column remains unchanged.
+//
+// Numeric columns are transformed without boxing their values, which makes MapFloat the preferred way to rescale numeric data.
+// Large numeric columns are split into chunks transformed in parallel (see SetWorkers), so f may be called concurrently and must not depend on the order of the calls.
+func (c *Column) MapFloat(f func(float64) float64) *Column {
+	var data vector
+
+	switch vec := c.data.(type) {
+	case *typedVector[float64]:
+		data = mapFloats(vec, func(x float64) float64 { return f(x) })
+	case *typedVector[int64]:
+		data = mapFloats(vec, func(x int64) float64 { return f(float64(x)) })
+	case *typedVector[int]:
+		data = mapFloats(vec, func(x int) float64 { return f(float64(x)) })
+	default:
+		return c.Map(func(v any) any {
+			x, ok := numeric.ToFloat64(v)
+			if !ok {
+				return v
+			}
+			return f(x)
+		})
+	}
+
+	return &Column{
+		name: c.name,
+		data: data,
+	}
+}
+
+func mapFloats[T any](v *typedVector[T], f func(T) float64) *typedVector[float64] {
+	result := &typedVector[float64]{
+		typ:    TypeFloat,
+		values: make([]float64, len(v.values)),
+		valid:  v.valid,
+	}
+
+	parallel.Chunks(context.Background(), len(v.values), parallelChunk, func(from, to int) {
+		for i := from; i < to; i++ {
+			if v.valid.get(i) {
+				result.values[i] = f(v.values[i])
+			}
+		}
+	})
+
+	return result
+}
+
 // Normalize performs Min-Max normalization on the column.
 //
 // The values are rescaled to the range [0, 1] using the formula:
@@ -51,6 +101,12 @@ func (c *Column) Normalize() (*Column, error) {
 		return nil, fmt.Errorf("normalize: min equals max")
 	}
 
+	if c.isNumeric() {
+		return c.MapFloat(func(x float64) float64 {
+			return (x - min) / (max - min)
+		}), nil
+	}
+
 	values := c.Values()
 	result := make([]any, 0, len(values))
 
@@ -71,7 +127,7 @@ func (c *Column) Normalize() (*Column, error) {
 
 	return &Column{
 		name: c.name,
-		data: result,
+		data: newVector(result),
 	}, nil
 }
 
@@ -100,6 +156,12 @@ func (c *Column) Standardize() (*Column, error) {
 		return nil, fmt.Errorf("standardize: standard deviation is zero")
 	}
 
+	if c.isNumeric() {
+		return c.MapFloat(func(x float64) float64 {
+			return (x - mean) / std
+		}), nil
+	}
+
 	values := c.Values()
 	result := make([]any, 0, len(values))
 
@@ -120,13 +182,13 @@ func (c *Column) Standardize() (*Column, error) {
 
 	return &Column{
 		name: c.name,
-		data: result,
+		data: newVector(result),
 	}, nil
 }
 
 // Categorize returns a new Column with encoded integer values.
 //
-// The new column named "<column>_categorized" is appended. Each unique value in the original column is mapped to a zero-based integer, preserving row order.
+// The new column named "<column>_categorized" is appended. Each unique value in the original column is mapped to a zero-based integer, preserving row order. Missing values stay missing.
 //
 // The original Column is not modified.
 func (c *Column) Categorize() (*Column, error) {
@@ -138,7 +200,7 @@ func (c *Column) Categorize() (*Column, error) {
 
 	return &Column{
 		name:        headerName,
-		data:        ctgData,
+		data:        newVector(ctgData),
 		categorical: true,
 	}, nil
 }
@@ -12,12 +12,7 @@ func (c *Column) Sum() (float64, bool) {
 	var sum float64
 	found := false
 
-	for _, v := range c.data {
-		n, ok := asNumeric(v)
-		if !ok {
-			continue
-		}
-
+	for _, n := range numericValues(c.data) {
 		sum += n
 		found = true
 	}
@@ -32,12 +27,7 @@ func (c *Column) Mean() (float64, bool) {
 	var sum float64
 	count := 0
 
-	for _, v := range c.data {
-		n, ok := asNumeric(v)
-		if !ok {
-			continue
-		}
-
+	for _, n := range numericValues(c.data) {
 		sum += n
 		count++
 	}
@@ -56,12 +46,7 @@ func (c *Column) Min() (float64, bool) {
 	var min float64
 	firstMark := true
 
-	for _, v := range c.data {
-		n, ok := asNumeric(v)
-		if !ok {
-			continue
-		}
-
+	for _, n := range numericValues(c.data) {
 		if firstMark || n < min {
 			min = n
 			firstMark = false
@@ -78,12 +63,7 @@ func (c *Column) Max() (float64, bool) {
 	var max float64
 	firstMark := true
 
-	for _, v := range c.data {
-		n, ok := asNumeric(v)
-		if !ok {
-			continue
-		}
-
+	for _, n := range numericValues(c.data) {
 		if firstMark || n > max {
 			max = n
 			firstMark = false
@@ -100,18 +80,12 @@ func (c *Column) Std() (float64, bool) {
 	var (
 		sum         float64
 		count       int
-		numericData = []float64{}
+		numericData = numericValues(c.data)
 	)
 
-	for _, v := range c.data {
-		n, ok := asNumeric(v)
-		if !ok {
-			continue
-		}
-
+	for _, n := range numericData {
 		sum += n
 		count++
-		numericData = append(numericData, n)
 	}
 
 	if count < 2 {
@@ -132,38 +106,19 @@ func (c *Column) Std() (float64, bool) {
 
 // Count returns the number of non-missing values in the column.
 //
-// A value is considered missing if it is nil or an empty string.
+// A value is considered missing if it is nil, see IsNull.
 func (c *Column) Count() int {
-	count := 0
-
-	for _, v := range c.data {
-		if v == nil {
-			continue
-		}
-
-		if s, ok := v.(string); ok && s == "" {
-			continue
-		}
-
-		count++
-	}
-
-	return count
+	return c.data.Len() - c.Missing()
 }
 
 // Missing returns the number of missing values in the column.
 //
-// A value is considered missing if it is nil or an empty string.
+// A value is considered missing if it is nil, see IsNull.
 func (c *Column) Missing() int {
 	missing := 0
 
-	for _, v := range c.data {
-		if v == nil {
-			missing++
-			continue
-		}
-
-		if s, ok := v.(string); ok && s == "" {
+	for i := 0; i < c.data.Len(); i++ {
+		if c.data.IsNull(i) {
 			missing++
 		}
 	}
@@ -171,18 +126,6 @@ func (c *Column) Missing() int {
 	return missing
 }
 
-func numericSlice(data []any) []float64 {
-	numSlice := make([]float64, 0, len(data))
-
-	for _, v := range data {
-		if n, ok := asNumeric(v); ok {
-			numSlice = append(numSlice, n)
-		}
-	}
-
-	return numSlice
-}
-
 // Quantile returns the q-th quantile of the numeric values in the column.
 //
 // The parameter q must be in the range [0, 1]. Non-numeric values are ignored. Linear interpolation is used between adjacent values.
@@ -192,7 +135,7 @@ func (c *Column) Quantile(q float64) (float64, bool) {
 		return 0, false
 	}
 
-	numSlice := numericSlice(c.data)
+	numSlice := append([]float64(nil), numericValues(c.data)...)
 	n := len(numSlice)
 	if n == 0 {
 		return 0, false
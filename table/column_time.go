@@ -0,0 +1,102 @@
+package table
+
+import "time"
+
+// Year returns a new Column holding the year of every time value in the column.
+//
+// Values that are not time.Time are returned as nil. The original Column is not modified.
+func (c *Column) Year() *Column {
+	return c.mapTime(func(t time.Time) any {
+		return int64(t.Year())
+	})
+}
+
+// Month returns a new Column holding the month (1 to 12) of every time value in the column.
+//
+// Values that are not time.Time are returned as nil. The original Column is not modified.
+func (c *Column) Month() *Column {
+	return c.mapTime(func(t time.Time) any {
+		return int64(t.Month())
+	})
+}
+
+// Day returns a new Column holding the day of the month of every time value in the column.
+//
+// Values that are not time.Time are returned as nil. The original Column is not modified.
+func (c *Column) Day() *Column {
+	return c.mapTime(func(t time.Time) any {
+		return int64(t.Day())
+	})
+}
+
+// Weekday returns a new Column holding the day of the week (0 for Sunday to 6 for Saturday) of every time value in the column.
+//
+// Values that are not time.Time are returned as nil. The original Column is not modified.
+func (c *Column) Weekday() *Column {
+	return c.mapTime(func(t time.Time) any {
+		return int64(t.Weekday())
+	})
+}
+
+// Truncate returns a new Column where every time value is rounded down to a multiple of d, as done by time.Time.Truncate.
+//
+// Values that are not time.Time are returned as nil. The original Column is not modified.
+func (c *Column) Truncate(d time.Duration) *Column {
+	return c.mapTime(func(t time.Time) any {
+		return t.Truncate(d)
+	})
+}
+
+// MinTime returns the earliest time value in the column.
+//
+// Values that are not time.Time are ignored. The second return value is false if the column contains no time values.
+func (c *Column) MinTime() (time.Time, bool) {
+	var min time.Time
+	firstMark := true
+
+	for i := 0; i < c.data.Len(); i++ {
+		t, ok := c.data.At(i).(time.Time)
+		if !ok {
+			continue
+		}
+
+		if firstMark || t.Before(min) {
+			min = t
+			firstMark = false
+		}
+	}
+
+	return min, !firstMark
+}
+
+// MaxTime returns the latest time value in the column.
+//
+// Values that are not time.Time are ignored. The second return value is false if the column contains no time values.
+func (c *Column) MaxTime() (time.Time, bool) {
+	var max time.Time
+	firstMark := true
+
+	for i := 0; i < c.data.Len(); i++ {
+		t, ok := c.data.At(i).(time.Time)
+		if !ok {
+			continue
+		}
+
+		if firstMark || t.After(max) {
+			max = t
+			firstMark = false
+		}
+	}
+
+	return max, !firstMark
+}
+
+func (c *Column) mapTime(f func(time.Time) any) *Column {
+	return c.Map(func(v any) any {
+		t, ok := v.(time.Time)
+		if !ok {
+			return nil
+		}
+		return f(t)
+	})
+}
@@ -0,0 +1,62 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestColumnTimeParts(t *testing.T) {
+	col := NewColumn("t", []any{
+		time.Date(2024, 2, 29, 13, 45, 0, 0, time.UTC),
+		nil,
+		time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC),
+	})
+
+	tests := []struct {
+		name string
+		got  *Column
+		want []any
+	}{
+		{name: "year", got: col.Year(), want: []any{int64(2024), nil, int64(2023)}},
+		{name: "month", got: col.Month(), want: []any{int64(2), nil, int64(12)}},
+		{name: "day", got: col.Day(), want: []any{int64(29), nil, int64(31)}},
+		{name: "weekday", got: col.Weekday(), want: []any{int64(time.Thursday), nil, int64(time.Sunday)}},
+		{
+			name: "truncate",
+			got:  col.Truncate(time.Hour),
+			want: []any{time.Date(2024, 2, 29, 13, 0, 0, 0, time.UTC), nil, time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("values = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	if col.Type() != TypeTime {
+		t.Errorf("type = %v, want %v", col.Type(), TypeTime)
+	}
+}
+
+func TestColumnMinMaxTime(t *testing.T) {
+	early := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	minTime, ok := NewColumn("t", []any{late, nil, early}).MinTime()
+	if !ok || !minTime.Equal(early) {
+		t.Errorf("MinTime = %v, %v, want %v, true", minTime, ok, early)
+	}
+
+	maxTime, ok := NewColumn("t", []any{late, nil, early}).MaxTime()
+	if !ok || !maxTime.Equal(late) {
+		t.Errorf("MaxTime = %v, %v, want %v, true", maxTime, ok, late)
+	}
+
+	if _, ok := NewColumn("t", []any{nil, 1}).MinTime(); ok {
+		t.Error("MinTime of a column without times: expected false")
+	}
+}
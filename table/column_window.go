@@ -0,0 +1,294 @@
+package table
+
+import (
+	"fmt"
+	"math"
+)
+
+// Shift returns a new Column with the values moved down by n rows, so that row i holds the value of row i-n. A negative n moves the values up.
+//
+// Rows without a source value become missing. The storage type of the column is preserved. The original Column remains unchanged.
+func (c *Column) Shift(n int) *Column {
+	length := c.data.Len()
+	indexes := make([]int, length)
+	for i := range indexes {
+		indexes[i] = i - n
+		if indexes[i] >= length {
+			indexes[i] = -1
+		}
+	}
+
+	return &Column{
+		name: c.name,
+		data: c.data.Take(indexes),
+	}
+}
+
+// Lag returns a new Column where row i holds the value of row i-n, see Shift.
+func (c *Column) Lag(n int) *Column {
+	return c.Shift(n)
+}
+
+// Lead returns a new Column where row i holds the value of row i+n, see Shift.
+func (c *Column) Lead(n int) *Column {
+	return c.Shift(-n)
+}
+
+// Diff returns a new Column holding the difference between each value and the value n rows before it, as float64.
+//
+// A row is missing if either value is missing or out of range. Only numeric columns are supported, an error is returned otherwise.
+func (c *Column) Diff(n int) (*Column, error) {
+	return c.pairwise("diff", n, func(x, prev float64) (float64, bool) {
+		return x - prev, true
+	})
+}
+
+// PctChange returns a new Column holding the relative change between each value and the value n rows before it, as float64.
+//
+// A row is missing if either value is missing or out of range, or if the previous value is zero. Only numeric columns are supported, an error is returned otherwise.
+func (c *Column) PctChange(n int) (*Column, error) {
+	return c.pairwise("pct change", n, func(x, prev float64) (float64, bool) {
+		if prev == 0 {
+			return 0, false
+		}
+		return (x - prev) / prev, true
+	})
+}
+
+func (c *Column) pairwise(op string, n int, f func(x, prev float64) (float64, bool)) (*Column, error) {
+	values, valid, err := c.floats(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, len(values))
+	resultValid := make([]bool, len(values))
+
+	for i := range values {
+		j := i - n
+		if j < 0 || j >= len(values) || !valid[i] || !valid[j] {
+			continue
+		}
+		result[i], resultValid[i] = f(values[i], values[j])
+	}
+
+	return &Column{
+		name: c.name,
+		data: newFloatVector(result, resultValid),
+	}, nil
+}
+
+// CumSum returns a new Column holding the running sum of the values, as float64.
+//
+// Missing values are skipped and stay missing in the result. Only numeric columns are supported, an error is returned otherwise.
+func (c *Column) CumSum() (*Column, error) {
+	return c.cumulative("cum sum", func(acc, x float64) float64 { return acc + x })
+}
+
+// CumProd returns a new Column holding the running product of the values, as float64.
+//
+// Missing values are skipped and stay missing in the result. Only numeric columns are supported, an error is returned otherwise.
+func (c *Column) CumProd() (*Column, error) {
+	return c.cumulative("cum prod", func(acc, x float64) float64 { return acc * x })
+}
+
+// CumMax returns a new Column holding the running maximum of the values, as float64.
+//
+// Missing values are skipped and stay missing in the result. Only numeric columns are supported, an error is returned otherwise.
+func (c *Column) CumMax() (*Column, error) {
+	return c.cumulative("cum max", math.Max)
+}
+
+// CumMin returns a new Column holding the running minimum of the values, as float64.
+//
+// Missing values are skipped and stay missing in the result. Only numeric columns are supported, an error is returned otherwise.
+func (c *Column) CumMin() (*Column, error) {
+	return c.cumulative("cum min", math.Min)
+}
+
+func (c *Column) cumulative(op string, f func(acc, x float64) float64) (*Column, error) {
+	values, valid, err := c.floats(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, len(values))
+	var (
+		acc     float64
+		started bool
+	)
+
+	for i, x := range values {
+		if !valid[i] {
+			continue
+		}
+
+		if started {
+			acc = f(acc, x)
+		} else {
+			acc = x
+			started = true
+		}
+		result[i] = acc
+	}
+
+	return &Column{
+		name: c.name,
+		data: newFloatVector(result, valid),
+	}, nil
+}
+
+// Rolling is a moving window over a Column, created with Column.Rolling.
+//
+// Each aggregation returns a new float64 Column where row i holds the aggregate of the window ending at row i. Missing values in the window are skipped.
+type Rolling struct {
+	column     *Column
+	window     int
+	minPeriods int
+}
+
+// Rolling returns a moving window of the given size over the column.
+//
+// By default a row of the result is missing unless its window holds window non-missing values, see Rolling.MinPeriods.
+func (c *Column) Rolling(window int) *Rolling {
+	return &Rolling{
+		column:     c,
+		window:     window,
+		minPeriods: window,
+	}
+}
+
+// MinPeriods returns a copy of the window that produces a value as soon as it holds at least n non-missing values.
+func (r *Rolling) MinPeriods(n int) *Rolling {
+	result := *r
+	result.minPeriods = n
+	return &result
+}
+
+// Sum returns the moving sum of the values.
+func (r *Rolling) Sum() (*Column, error) {
+	return r.apply("rolling sum", func(w []float64) (float64, bool) {
+		var sum float64
+		for _, x := range w {
+			sum += x
+		}
+		return sum, true
+	})
+}
+
+// Mean returns the moving mean of the values.
+func (r *Rolling) Mean() (*Column, error) {
+	return r.apply("rolling mean", func(w []float64) (float64, bool) {
+		var sum float64
+		for _, x := range w {
+			sum += x
+		}
+		return sum / float64(len(w)), true
+	})
+}
+
+// Std returns the moving sample standard deviation of the values. A row is missing if its window holds fewer than two values.
+func (r *Rolling) Std() (*Column, error) {
+	return r.apply("rolling std", func(w []float64) (float64, bool) {
+		return (&Column{data: newFloatVector(w, nil)}).Std()
+	})
+}
+
+// Min returns the moving minimum of the values.
+func (r *Rolling) Min() (*Column, error) {
+	return r.apply("rolling min", func(w []float64) (float64, bool) {
+		min := w[0]
+		for _, x := range w[1:] {
+			min = math.Min(min, x)
+		}
+		return min, true
+	})
+}
+
+// Max returns the moving maximum of the values.
+func (r *Rolling) Max() (*Column, error) {
+	return r.apply("rolling max", func(w []float64) (float64, bool) {
+		max := w[0]
+		for _, x := range w[1:] {
+			max = math.Max(max, x)
+		}
+		return max, true
+	})
+}
+
+func (r *Rolling) apply(op string, f func(window []float64) (float64, bool)) (*Column, error) {
+	if r.window <= 0 {
+		return nil, fmt.Errorf("%s: window must be positive, got %d", op, r.window)
+	}
+
+	minPeriods := r.minPeriods
+	if minPeriods < 1 {
+		minPeriods = 1
+	}
+
+	values, valid, err := r.column.floats(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, len(values))
+	resultValid := make([]bool, len(values))
+	window := make([]float64, 0, r.window)
+
+	for i := range values {
+		window = window[:0]
+		for j := max(0, i-r.window+1); j <= i; j++ {
+			if valid[j] {
+				window = append(window, values[j])
+			}
+		}
+
+		if len(window) < minPeriods {
+			continue
+		}
+		result[i], resultValid[i] = f(window)
+	}
+
+	return &Column{
+		name: r.column.name,
+		data: newFloatVector(result, resultValid),
+	}, nil
+}
+
+// floats returns the values of a numeric column as float64, together with their validity.
+func (c *Column) floats(op string) ([]float64, []bool, error) {
+	n := c.data.Len()
+	values := make([]float64, n)
+	valid := make([]bool, n)
+
+	for i := 0; i < n; i++ {
+		if c.data.IsNull(i) {
+			continue
+		}
+
+		x, ok := asNumeric(c.data.At(i))
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: column %s is not numeric", op, c.name)
+		}
+		values[i] = x
+		valid[i] = true
+	}
+
+	return values, valid, nil
+}
+
+// newFloatVector builds a float64 vector from values and their validity. A nil valid slice means every value is present.
+func newFloatVector(values []float64, valid []bool) *typedVector[float64] {
+	v := &typedVector[float64]{
+		typ:    TypeFloat,
+		values: values,
+	}
+
+	for i, ok := range valid {
+		if !ok {
+			v.valid.clear(i, len(values))
+		}
+	}
+
+	return v
+}
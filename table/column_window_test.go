@@ -0,0 +1,176 @@
+package table
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShift(t *testing.T) {
+	col := NewColumn("x", []any{int64(1), int64(2), nil, int64(4)})
+
+	tests := []struct {
+		name   string
+		column *Column
+		want   []any
+	}{
+		{"down", col.Shift(1), []any{nil, int64(1), int64(2), nil}},
+		{"up", col.Shift(-2), []any{nil, int64(4), nil, nil}},
+		{"zero", col.Shift(0), []any{int64(1), int64(2), nil, int64(4)}},
+		{"beyond length", col.Shift(5), []any{nil, nil, nil, nil}},
+		{"lag", col.Lag(1), []any{nil, int64(1), int64(2), nil}},
+		{"lead", col.Lead(1), []any{int64(2), nil, int64(4), nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.column.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("values = %#v, want %#v", got, tt.want)
+			}
+			if got := tt.column.Type(); got != TypeInt {
+				t.Errorf("type = %v, want %v", got, TypeInt)
+			}
+		})
+	}
+}
+
+func TestNumericWindows(t *testing.T) {
+	col := NewColumn("x", []any{int64(2), int64(4), nil, int64(1), int64(0), int64(3)})
+
+	tests := []struct {
+		name string
+		f    func() (*Column, error)
+		want []any
+	}{
+		{"diff", func() (*Column, error) { return col.Diff(1) }, []any{nil, 2.0, nil, nil, -1.0, 3.0}},
+		{"diff 2", func() (*Column, error) { return col.Diff(2) }, []any{nil, nil, nil, -3.0, nil, 2.0}},
+		{"diff negative", func() (*Column, error) { return col.Diff(-1) }, []any{-2.0, nil, nil, 1.0, -3.0, nil}},
+		{"pct change", func() (*Column, error) { return col.PctChange(1) }, []any{nil, 1.0, nil, nil, -1.0, nil}},
+		{"cum sum", col.CumSum, []any{2.0, 6.0, nil, 7.0, 7.0, 10.0}},
+		{"cum prod", col.CumProd, []any{2.0, 8.0, nil, 8.0, 0.0, 0.0}},
+		{"cum max", col.CumMax, []any{2.0, 4.0, nil, 4.0, 4.0, 4.0}},
+		{"cum min", col.CumMin, []any{2.0, 2.0, nil, 1.0, 0.0, 0.0}},
+		{"rolling sum", col.Rolling(2).Sum, []any{nil, 6.0, nil, nil, 1.0, 3.0}},
+		{"rolling sum min periods", col.Rolling(2).MinPeriods(1).Sum, []any{2.0, 6.0, 4.0, 1.0, 1.0, 3.0}},
+		{"rolling mean", col.Rolling(3).MinPeriods(2).Mean, []any{nil, 3.0, 3.0, 2.5, 0.5, 4.0 / 3}},
+		{"rolling min", col.Rolling(3).MinPeriods(1).Min, []any{2.0, 2.0, 2.0, 1.0, 0.0, 0.0}},
+		{"rolling max", col.Rolling(3).MinPeriods(1).Max, []any{2.0, 4.0, 4.0, 4.0, 1.0, 3.0}},
+		{"rolling std", col.Rolling(2).MinPeriods(1).Std, []any{nil, math.Sqrt(2), nil, nil, math.Sqrt(0.5), math.Sqrt(4.5)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.f()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Name() != "x" {
+				t.Errorf("name = %q, want x", result.Name())
+			}
+			if result.Type() != TypeFloat {
+				t.Errorf("type = %v, want %v", result.Type(), TypeFloat)
+			}
+
+			got := result.Values()
+			if len(got) != len(tt.want) {
+				t.Fatalf("values = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				g, _ := got[i].(float64)
+				w, _ := tt.want[i].(float64)
+				if (got[i] == nil) != (tt.want[i] == nil) || math.Abs(g-w) > 1e-12 {
+					t.Fatalf("values = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNumericWindowErrors(t *testing.T) {
+	text := NewColumn("name", []any{"a", "b"})
+	numbers := NewColumn("x", []any{1.0, 2.0})
+
+	tests := []struct {
+		name string
+		f    func() (*Column, error)
+		want string
+	}{
+		{"diff", func() (*Column, error) { return text.Diff(1) }, "diff: column name is not numeric"},
+		{"cum sum", text.CumSum, "cum sum: column name is not numeric"},
+		{"rolling", text.Rolling(2).Mean, "rolling mean: column name is not numeric"},
+		{"zero window", numbers.Rolling(0).Sum, "rolling sum: window must be positive, got 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.f()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupedTransform(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"product": {"a", "b", "a", "b", "a"},
+		"price":   {1.0, 10.0, 2.0, 12.0, 4.0},
+	}, "product", "price")
+
+	g, err := tbl.GroupBy("product")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := g.Transform("price", "prev_price", func(c *Column) (*Column, error) {
+		return c.Lag(1), nil
+	})
+	if err != nil {
+		t.Fatalf("Transform: %v", err)
+	}
+	assertColumns(t, result, "product", "price", "prev_price")
+	assertValues(t, result, "prev_price", nil, nil, 1.0, 10.0, 2.0)
+
+	result, err = g.Transform("price", "price", (*Column).CumSum)
+	if err != nil {
+		t.Fatalf("Transform: %v", err)
+	}
+	assertColumns(t, result, "product", "price")
+	assertValues(t, result, "price", 1.0, 10.0, 3.0, 22.0, 7.0)
+	assertValues(t, tbl, "price", 1.0, 10.0, 2.0, 12.0, 4.0)
+}
+
+func TestGroupedTransformErrors(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"product": {"a", "b", "a"},
+		"price":   {1.0, 10.0, 2.0},
+	}, "product", "price")
+
+	g, err := tbl.GroupBy("product")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		col  string
+		f    func(*Column) (*Column, error)
+		want string
+	}{
+		{"missing column", "nope", (*Column).CumSum, "column nope does not exist"},
+		{"function error", "product", (*Column).CumSum, "cum sum: column product is not numeric"},
+		{"nil column", "price", func(*Column) (*Column, error) { return nil, nil }, "function returned no column"},
+		{"wrong length", "price", func(*Column) (*Column, error) { return NewColumn("x", []any{1.0}), nil }, "returned 1 values for a group of 2 rows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := g.Transform(tt.col, "out", tt.f)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package table
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// compareValues returns -1, 0 or +1 depending on whether a sorts before, equal to or after b.
+//
+// Numeric values are compared numerically regardless of their Go type, so int64(2) sorts before float64(2.5). Integers are compared exactly,
+// and only pairs involving a float64 are compared as float64 values, consistent with asNumeric.
+// Values of different kinds are ordered by kind: booleans, numbers, strings, times, then any other type, which is compared by its formatted representation.
+// Missing values are not handled here, callers decide where they sort.
+func compareValues(a, b any) int {
+	ka, kb := valueKind(a), valueKind(b)
+	if ka != kb {
+		return cmp.Compare(ka, kb)
+	}
+
+	switch ka {
+	case kindBool:
+		x, y := a.(bool), b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		default:
+			return 1
+		}
+	case kindNumber:
+		if x, ok := asInt(a); ok {
+			if y, ok := asInt(b); ok {
+				return cmp.Compare(x, y)
+			}
+		}
+		x, _ := asNumeric(a)
+		y, _ := asNumeric(b)
+		return cmp.Compare(x, y)
+	case kindString:
+		return strings.Compare(a.(string), b.(string))
+	case kindTime:
+		return a.(time.Time).Compare(b.(time.Time))
+	default:
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+const (
+	kindBool = iota
+	kindNumber
+	kindString
+	kindTime
+	kindOther
+)
+
+func valueKind(v any) int {
+	switch v.(type) {
+	case bool:
+		return kindBool
+	case int, int64, float64:
+		return kindNumber
+	case string:
+		return kindString
+	case time.Time:
+		return kindTime
+	default:
+		return kindOther
+	}
+}
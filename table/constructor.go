@@ -58,9 +58,14 @@ func New(data map[string][]any, columnsOrder ...[]string) (*Table, error) {
 		}
 	}
 
+	vectors := make(map[string]vector, len(columns))
+	for _, col := range columns {
+		vectors[col] = newVector(data[col])
+	}
+
 	return &Table{
 		columns: columns,
-		data:    data,
+		data:    vectors,
 		length:  length,
 	}, nil
 }
@@ -76,7 +81,7 @@ func New(data map[string][]any, columnsOrder ...[]string) (*Table, error) {
 func NewEmptyTable() *Table {
 	return &Table{
 		columns: []string{},
-		data:    map[string][]any{},
+		data:    map[string]vector{},
 		length:  0,
 	}
 }
@@ -90,11 +95,11 @@ func NewEmptyTable() *Table {
 //   fmt.Println(t2.Columns())  // same as t.Columns()
 func EmptyTableFrom(t *Table) *Table {
 	columns := make([]string, 0, len(t.columns))
-	data := make(map[string][]any, len(t.columns))
+	data := make(map[string]vector, len(t.columns))
 
 	for _, c := range t.columns {
 		columns = append(columns, c)
-		data[c] = []any{}
+		data[c] = emptyVector(t.data[c])
 	}
 
 	return &Table{
@@ -2,25 +2,58 @@ package table
 
 import "fmt"
 
+// DisplayOption configures how Display and DisplayTranspose render a table.
+type DisplayOption func(*displayOptions)
+
+type displayOptions struct {
+	nullMarker string
+}
+
+func defaultDisplayOptions() displayOptions {
+	return displayOptions{
+		nullMarker: "null",
+	}
+}
+
+func newDisplayOptions(argOpts []DisplayOption) displayOptions {
+	opts := defaultDisplayOptions()
+	for _, opt := range argOpts {
+		opt(&opts)
+	}
+	return opts
+}
+
+// WithNullMarker returns a DisplayOption that sets the text rendered for missing values, such as "NaN" or "-".
+//
+// The default marker is "null".
+func WithNullMarker(marker string) DisplayOption {
+	return func(o *displayOptions) {
+		o.nullMarker = marker
+	}
+}
+
 // Display prints the table to the standard output.
 //
-// It renders all rows of the table using the current column order.
+// It renders all rows of the table using the current column order. Missing values are rendered as "null" unless another marker is set with WithNullMarker.
 // If the table is nil, the string "nil" is printed instead.
-func (t *Table) Display() {
+func (t *Table) Display(opts ...DisplayOption) {
 	if t == nil {
 		fmt.Println("nil")
 		return
 	}
 
 	indexes := firstIndexes(t.Len(), t.Len())
-	displayByIndexes(t, indexes)
+	displayByIndexes(t, indexes, newDisplayOptions(opts))
 }
 
-func (t *Table) DisplayTranspose() {
+// DisplayTranspose prints the table to the standard output with columns rendered as rows.
+//
+// It accepts the same options as Display.
+func (t *Table) DisplayTranspose(opts ...DisplayOption) {
 	if t == nil {
 		fmt.Println("nil")
 		return
 	}
 
-	displayTranspose(t)
+	displayTranspose(t, newDisplayOptions(opts))
 }
@@ -3,9 +3,10 @@ package table
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
-func columnWidths(t *Table, indexes []int) map[any]int {
+func columnWidths(t *Table, indexes []int, opts displayOptions) map[any]int {
 	widths := make(map[any]int)
 
 	for _, col := range t.Columns() {
@@ -14,7 +15,7 @@ func columnWidths(t *Table, indexes []int) map[any]int {
 
 	for _, i := range indexes {
 		for _, col := range t.Columns() {
-			val := stringValue(t.data[col][i])
+			val := stringValue(t.data[col].At(i), opts)
 			lenVal := len(val)
 			if lenVal > widths[col] {
 				widths[col] = lenVal
@@ -25,7 +26,7 @@ func columnWidths(t *Table, indexes []int) map[any]int {
 	return widths
 }
 
-func columnWidthsTranspose(t *Table) map[any]int {
+func columnWidthsTranspose(t *Table, opts displayOptions) map[any]int {
 	widths := make(map[any]int)
 
 	columns := t.Columns()
@@ -52,7 +53,7 @@ func columnWidthsTranspose(t *Table) map[any]int {
 		}
 
 		for _, c := range t.columns {
-			val := fmt.Sprint(t.data[c][i-1])
+			val := stringValue(t.data[c].At(i-1), opts)
 			lenVal := len(val)
 			if lenVal > maxLen {
 				maxLen = lenVal
@@ -117,12 +118,16 @@ func renderSeparatorTranspose(widths map[any]int) string {
 	return sb.String()
 }
 
-func stringValue(value any) string {
+func stringValue(value any, opts displayOptions) string {
 	var strVal string
 
 	switch v := value.(type) {
+	case nil:
+		strVal = opts.nullMarker
 	case float64, float32:
 		strVal = fmt.Sprintf("%.2f", v)
+	case time.Time:
+		strVal = formatTime(v)
 	default:
 		strVal = fmt.Sprint(v)
 	}
@@ -130,26 +135,34 @@ func stringValue(value any) string {
 	return strVal
 }
 
-func renderCell(value any, width int) string {
+// formatTime renders a time value compactly, omitting the clock for values at midnight.
+func formatTime(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.DateTime)
+}
+
+func renderCell(value any, width int, opts displayOptions) string {
 	var s string
 
-	if isNumeric(value) {
-		strVal := stringValue(value)
+	if value == nil || isNumeric(value) {
+		strVal := stringValue(value, opts)
 		s = " " + padCenter(strVal, width) + " |"
 	} else {
-		s = " " + padRight(fmt.Sprint(value), width) + " |"
+		s = " " + padRight(stringValue(value, opts), width) + " |"
 	}
 
 	return s
 }
 
-func renderRow(t *Table, row int, widths map[any]int) string {
+func renderRow(t *Table, row int, widths map[any]int, opts displayOptions) string {
 	var sb strings.Builder
 	sb.WriteString("|")
 
 	for _, col := range t.Columns() {
-		val := t.data[col][row]
-		sb.WriteString(renderCell(val, widths[col]))
+		val := t.data[col].At(row)
+		sb.WriteString(renderCell(val, widths[col], opts))
 	}
 
 	return sb.String()
@@ -5,7 +5,7 @@ import (
 	"strings"
 )
 
-func displayByIndexes(t *Table, indexes []int) {
+func displayByIndexes(t *Table, indexes []int, opts displayOptions) {
 	if t == nil {
 		fmt.Println("nil")
 		return
@@ -16,7 +16,7 @@ func displayByIndexes(t *Table, indexes []int) {
 		return
 	}
 
-	widths := columnWidths(t, indexes)
+	widths := columnWidths(t, indexes, opts)
 
 	var sb strings.Builder
 
@@ -32,7 +32,7 @@ func displayByIndexes(t *Table, indexes []int) {
 	sb.WriteString("\n")
 
 	for _, i := range indexes {
-		sb.WriteString(renderRow(t, i, widths))
+		sb.WriteString(renderRow(t, i, widths, opts))
 		sb.WriteString("\n")
 	}
 
@@ -42,7 +42,7 @@ func displayByIndexes(t *Table, indexes []int) {
 	fmt.Println(sb.String())
 }
 
-func displayTranspose(t *Table) {
+func displayTranspose(t *Table, opts displayOptions) {
 	if t == nil {
 		fmt.Println("nil")
 		return
@@ -53,7 +53,7 @@ func displayTranspose(t *Table) {
 		return
 	}
 
-	widths := columnWidthsTranspose(t)
+	widths := columnWidthsTranspose(t, opts)
 
 	var sb strings.Builder
 
@@ -64,10 +64,10 @@ func displayTranspose(t *Table) {
 
 	for _, col := range t.columns {
 		sb.WriteString("|")
-		sb.WriteString(renderCell(col, widths[0]))
+		sb.WriteString(renderCell(col, widths[0], opts))
 
-		for i, val := range t.data[col] {
-			sb.WriteString(renderCell(val, widths[i+1]))
+		for i, val := range t.data[col].Values() {
+			sb.WriteString(renderCell(val, widths[i+1], opts))
 		}
 
 		sb.WriteString("\n")
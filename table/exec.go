@@ -0,0 +1,22 @@
+package table
+
+import "github.com/go-rowan/rowan/internal/parallel"
+
+// parallelChunk is the minimum number of rows processed by a goroutine when element-wise work on a single column is split into chunks.
+const parallelChunk = 32 * 1024
+
+// SetWorkers sets the number of goroutines used by column-wise operations, such as Stats, Categorize and MapFloat, and by the scalers of the scale package.
+//
+// Work is split per column, and large columns are further split into chunks of rows. The workers are shared by all operations running at the same time,
+// so splitting a column while columns are processed in parallel does not start more than n goroutines. Results do not depend on the number of workers.
+// A value of zero or less restores the default, runtime.GOMAXPROCS(0). A value of 1 runs everything on the calling goroutine.
+//
+// The setting applies to the whole process and may be changed at any time; operations already running keep the value they started with.
+func SetWorkers(n int) {
+	parallel.SetWorkers(n)
+}
+
+// Workers returns the number of goroutines used by column-wise operations, see SetWorkers.
+func Workers() int {
+	return parallel.Workers()
+}
@@ -0,0 +1,211 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/go-rowan/rowan/internal/expr"
+)
+
+// Expr is a parsed expression over the columns of a table, used by Filter and WithColumn.
+//
+// An expression combines column names, literals, operators and functions:
+//   - literals: numbers (42, 3.5), strings ('ID' or "ID"), true, false and null
+//   - column names; names that are not plain words are enclosed in backticks, as in `first name`
+//   - arithmetic: + - * / % and ^ (power); + also concatenates strings, / and ^ always give a float
+//   - comparison: == (or =), != (or <>), <, <=, >, >=, and x between a and b, x in (a, b, ...), x like 'A%'
+//   - boolean logic: && (or and), || (or or), ! (or not)
+//   - null handling: x is null, x is not null, coalesce(x, y, ...), isnull(x), if(cond, a, b)
+//   - string functions: lower, upper, trim, len, contains, startswith, endswith, replace, substr (1-based) and concat
+//   - numeric functions: abs, round, floor, ceil, sqrt, pow, log and exp
+//   - time functions: year, month and day; a time column compared with a string literal such as '2024-01-31' parses the literal as a time
+//
+// Operations involving a null value give null, except for the null handling functions and for && and ||, which follow SQL three-valued logic.
+// Division by zero gives null. Integer arithmetic that overflows int64 fails on that row rather than wrapping around.
+// The values of coalesce and the branches of if must share a kind, with integers and floats giving a float.
+//
+// An Expr is parsed once and can be applied to any number of tables. It is type-checked against the column types of each table it is applied to.
+type Expr struct {
+	src  string
+	node expr.Node
+}
+
+// ParseExpr parses an expression, see Expr. An error is returned if the expression is not syntactically valid.
+func ParseExpr(s string) (*Expr, error) {
+	node, err := expr.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{src: s, node: node}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Columns returns the names of the columns referenced by the expression, in order of first appearance.
+func (e *Expr) Columns() []string {
+	cols := []string{}
+	expr.Walk(e.node, func(n expr.Node) bool {
+		id, ok := n.(*expr.Ident)
+		if !ok {
+			return true
+		}
+
+		name := id.Name
+		if id.Qualifier != "" {
+			name = id.Qualifier + "." + name
+		}
+		if !containsColumn(cols, name) {
+			cols = append(cols, name)
+		}
+		return true
+	})
+	return cols
+}
+
+// Filter returns a new Table containing only the rows for which the boolean expression is true, see Expr.
+//
+// Rows where the expression is null are dropped. For example:
+//
+//	adults, err := t.Filter("age >= 18 && country == 'ID'")
+//
+// An error is returned if the expression is invalid, refers to a column that does not exist, is not boolean, or fails on a row. The original Table is not modified.
+func (t *Table) Filter(expression string) (*Table, error) {
+	e, err := ParseExpr(expression)
+	if err != nil {
+		return nil, err
+	}
+	return t.FilterExpr(e)
+}
+
+// FilterExpr is like Filter, for an expression parsed with ParseExpr.
+func (t *Table) FilterExpr(e *Expr) (*Table, error) {
+	program, row, err := t.compile(e)
+	if err != nil {
+		return nil, err
+	}
+
+	switch program.Kind() {
+	case expr.KindBool, expr.KindNull, expr.KindUnknown:
+	default:
+		return nil, fmt.Errorf("filter: expression must be boolean, got %s", program.Kind())
+	}
+
+	indexes := []int{}
+	for i := 0; i < t.length; i++ {
+		v, err := program.Eval(row(i))
+		if err != nil {
+			return nil, fmt.Errorf("filter: row %d: %w", i, err)
+		}
+
+		switch b := v.(type) {
+		case nil:
+		case bool:
+			if b {
+				indexes = append(indexes, i)
+			}
+		default:
+			return nil, fmt.Errorf("filter: row %d: expression must be boolean, got %T", i, v)
+		}
+	}
+
+	return t.fetchRows(indexes), nil
+}
+
+// WithColumn returns a new Table with a column computed from an expression for every row, see Expr. For example:
+//
+//	t2, err := t.WithColumn("bmi", "weight / (height/100)^2")
+//
+// If a column with that name exists, it is replaced in place; otherwise the column is appended.
+// An error is returned if the name is empty, if the expression is invalid, refers to a column that does not exist, or fails on a row. The original Table is not modified.
+func (t *Table) WithColumn(name, expression string) (*Table, error) {
+	e, err := ParseExpr(expression)
+	if err != nil {
+		return nil, err
+	}
+	return t.WithColumnExpr(name, e)
+}
+
+// WithColumnExpr is like WithColumn, for an expression parsed with ParseExpr.
+func (t *Table) WithColumnExpr(name string, e *Expr) (*Table, error) {
+	if name == "" {
+		return nil, fmt.Errorf("with column: column name can not be empty")
+	}
+
+	program, row, err := t.compile(e)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, t.length)
+	for i := range values {
+		v, err := program.Eval(row(i))
+		if err != nil {
+			return nil, fmt.Errorf("with column: row %d: %w", i, err)
+		}
+		values[i] = v
+	}
+
+	result := t.copy()
+	if _, exists := result.data[name]; !exists {
+		result.columns = append(result.columns, name)
+	}
+	result.data[name] = newVector(values)
+
+	return result, nil
+}
+
+// compile type-checks e against the columns of the table, and returns the program together with a function giving access to row i.
+func (t *Table) compile(e *Expr) (*expr.Program, func(i int) expr.Row, error) {
+	vectors := []vector{}
+	indexes := map[string]int{}
+
+	resolve := func(qualifier, name string) (int, expr.Kind, error) {
+		if qualifier != "" {
+			name = qualifier + "." + name
+		}
+
+		v, ok := t.data[name]
+		if !ok {
+			return 0, 0, fmt.Errorf("expr: column %s not found", name)
+		}
+
+		index, ok := indexes[name]
+		if !ok {
+			index = len(vectors)
+			indexes[name] = index
+			vectors = append(vectors, v)
+		}
+		return index, exprKind(v.Type()), nil
+	}
+
+	program, err := expr.Compile(e.node, resolve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	row := func(i int) expr.Row {
+		return func(index int) any {
+			return vectors[index].At(i)
+		}
+	}
+	return program, row, nil
+}
+
+func exprKind(t Type) expr.Kind {
+	switch t {
+	case TypeBool:
+		return expr.KindBool
+	case TypeInt:
+		return expr.KindInt
+	case TypeFloat:
+		return expr.KindFloat
+	case TypeString:
+		return expr.KindString
+	case TypeTime:
+		return expr.KindTime
+	default:
+		return expr.KindUnknown
+	}
+}
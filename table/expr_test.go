@@ -0,0 +1,103 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func exprTable(tb testing.TB) *Table {
+	return mustNew(tb, map[string][]any{
+		"name":    {"Ann", "Bob", "Cid", nil},
+		"age":     {int64(34), int64(17), nil, int64(52)},
+		"country": {"ID", "ID", "NO", "ID"},
+		"mixed":   {int64(1), "x", 2.5, nil},
+	}, "name", "age", "country", "mixed")
+}
+
+func TestFilter(t *testing.T) {
+	tbl := exprTable(t)
+
+	tests := []struct {
+		expression string
+		names      []any
+	}{
+		{"age >= 18 && country == 'ID'", []any{"Ann", nil}},
+		{"age < 18 or age is null", []any{"Bob", "Cid"}},
+		{"name like '_o%'", []any{"Bob"}},
+		{"country in ('NO', 'SE')", []any{"Cid"}},
+		{"mixed == 1", []any{"Ann"}},
+		{"if(age > 40, 'senior', 'adult') == 'senior'", []any{nil}},
+		{"false", []any{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			result, err := tbl.Filter(tt.expression)
+			if err != nil {
+				t.Fatalf("Filter: %v", err)
+			}
+			assertColumns(t, result, "name", "age", "country", "mixed")
+			assertValues(t, result, "name", tt.names...)
+		})
+	}
+}
+
+func TestWithColumn(t *testing.T) {
+	tbl := exprTable(t)
+
+	tests := []struct {
+		name       string
+		expression string
+		columns    []string
+		want       []any
+		typ        Type
+	}{
+		{"next_age", "age + 1", []string{"name", "age", "country", "mixed", "next_age"}, []any{int64(35), int64(18), nil, int64(53)}, TypeInt},
+		{"age", "age / 2", []string{"name", "age", "country", "mixed"}, []any{17.0, 8.5, nil, 26.0}, TypeFloat},
+		{"label", "coalesce(name, '?') + '/' + country", []string{"name", "age", "country", "mixed", "label"}, []any{"Ann/ID", "Bob/ID", "Cid/NO", "?/ID"}, TypeString},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tbl.WithColumn(tt.name, tt.expression)
+			if err != nil {
+				t.Fatalf("WithColumn: %v", err)
+			}
+			assertColumns(t, result, tt.columns...)
+			assertValues(t, result, tt.name, tt.want...)
+			if got := result.data[tt.name].Type(); got != tt.typ {
+				t.Errorf("type = %v, want %v", got, tt.typ)
+			}
+		})
+	}
+
+	assertColumns(t, tbl, "name", "age", "country", "mixed")
+}
+
+func TestExprErrors(t *testing.T) {
+	tbl := exprTable(t)
+
+	tests := []struct {
+		name string
+		f    func() error
+		want string
+	}{
+		{"syntax", func() error { _, err := tbl.Filter("age >"); return err }, "expr:"},
+		{"missing column", func() error { _, err := tbl.Filter("height > 1"); return err }, "column height not found"},
+		{"not boolean", func() error { _, err := tbl.Filter("age + 1"); return err }, "bool"},
+		{"type mismatch", func() error { _, err := tbl.Filter("name > 3"); return err }, "cannot compare string and int"},
+		{"if branches", func() error { _, err := tbl.WithColumn("x", "if(age > 18, name, 0)"); return err }, "function if expects arguments of the same kind, got string and int"},
+		{"row error", func() error { _, err := tbl.Filter("mixed > 1"); return err }, "cannot compare string and int"},
+		{"overflow", func() error { _, err := tbl.WithColumn("x", "age * 9223372036854775807"); return err }, "integer overflow"},
+		{"empty name", func() error { _, err := tbl.WithColumn("", "1"); return err }, "column name can not be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.f()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
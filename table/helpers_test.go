@@ -0,0 +1,65 @@
+package table
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// mustNew builds a Table from data with the given column order, failing the test on error.
+func mustNew(tb testing.TB, data map[string][]any, columns ...string) *Table {
+	tb.Helper()
+
+	t, err := New(data, columns)
+	if err != nil {
+		tb.Fatalf("New: %v", err)
+	}
+	return t
+}
+
+// assertColumns checks the column order of t.
+func assertColumns(tb testing.TB, t *Table, want ...string) {
+	tb.Helper()
+
+	if got := t.Columns(); !reflect.DeepEqual(got, want) {
+		tb.Fatalf("columns = %v, want %v", got, want)
+	}
+}
+
+// assertValues checks the values of column c of t.
+func assertValues(tb testing.TB, t *Table, c string, want ...any) {
+	tb.Helper()
+
+	col, err := t.Col(c)
+	if err != nil {
+		tb.Fatalf("Col(%q): %v", c, err)
+	}
+	if got := col.Values(); !reflect.DeepEqual(got, want) {
+		tb.Errorf("column %s = %#v, want %#v", c, got, want)
+	}
+}
+
+// captureStdout returns what f prints to the standard output.
+func captureStdout(tb testing.TB, f func()) string {
+	tb.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+
+	f()
+	w.Close()
+	return <-done
+}
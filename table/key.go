@@ -0,0 +1,58 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// rowKey encodes the values of row i in the given vectors into a string usable as a map key.
+//
+// Values that compare equal with compareValues produce the same key: integers, and floating point values holding an integer that fits in an int64,
+// are encoded by their exact integer value, so int64(1) and float64(1) fall into the same group while large distinct integers do not.
+// Missing values get their own encoding, distinct from every other value.
+//
+// Every value is encoded with its length, so that no two different rows share a key whatever bytes their strings hold.
+func rowKey(vectors []vector, i int) string {
+	var b []byte
+	for _, v := range vectors {
+		b = appendKey(b, v.At(i))
+	}
+	return string(b)
+}
+
+// appendKey appends a tag for the kind of v, the length of its text and the text to b.
+func appendKey(b []byte, v any) []byte {
+	tag, text := keyText(v)
+	b = append(b, tag)
+	b = strconv.AppendInt(b, int64(len(text)), 10)
+	b = append(b, ':')
+	return append(b, text...)
+}
+
+func keyText(v any) (byte, string) {
+	if v == nil {
+		return 'n', ""
+	}
+
+	switch valueKind(v) {
+	case kindBool:
+		return 'b', strconv.FormatBool(v.(bool))
+	case kindNumber:
+		if n, ok := asInt(v); ok {
+			return 'i', strconv.FormatInt(n, 10)
+		}
+		f := v.(float64)
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return 'i', strconv.FormatInt(int64(f), 10)
+		}
+		return 'f', strconv.FormatFloat(f, 'g', -1, 64)
+	case kindString:
+		return 's', v.(string)
+	case kindTime:
+		return 't', v.(time.Time).UTC().Format(time.RFC3339Nano)
+	default:
+		return 'o', fmt.Sprint(v)
+	}
+}
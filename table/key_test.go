@@ -0,0 +1,90 @@
+package table
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRowKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   map[string][]any
+		groups int
+	}{
+		{
+			name:   "integers above 2^53",
+			data:   map[string][]any{"a": {int64(9007199254740993), int64(9007199254740992)}, "b": {"x", "x"}},
+			groups: 2,
+		},
+		{
+			name:   "integer and integral float",
+			data:   map[string][]any{"a": {int64(1), 1.0}, "b": {"x", "x"}},
+			groups: 1,
+		},
+		{
+			name:   "large integer and float",
+			data:   map[string][]any{"a": {int64(1 << 62), float64(1 << 62)}, "b": {"x", "x"}},
+			groups: 1,
+		},
+		{
+			name:   "integer and fractional float",
+			data:   map[string][]any{"a": {int64(1), 1.5}, "b": {"x", "x"}},
+			groups: 2,
+		},
+		{
+			name:   "floats beyond int64",
+			data:   map[string][]any{"a": {math.Pow(2, 64), math.Pow(2, 64)}, "b": {"x", "x"}},
+			groups: 1,
+		},
+		{
+			name:   "strings holding NUL bytes",
+			data:   map[string][]any{"a": {"x\x00sy", "x"}, "b": {"z", "y\x00sz"}},
+			groups: 2,
+		},
+		{
+			name:   "missing value and text",
+			data:   map[string][]any{"a": {nil, "n"}, "b": {"x", "x"}},
+			groups: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := mustNew(t, tt.data, "a", "b")
+
+			g, err := tbl.GroupBy("a", "b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if g.Len() != tt.groups {
+				t.Errorf("GroupBy: %d groups, want %d", g.Len(), tt.groups)
+			}
+
+			distinct, err := tbl.Distinct()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if distinct.Len() != tt.groups {
+				t.Errorf("Distinct: %d rows, want %d", distinct.Len(), tt.groups)
+			}
+		})
+	}
+}
+
+func TestJoinLargeIntegerKeys(t *testing.T) {
+	left := mustNew(t, map[string][]any{
+		"id":   {int64(9007199254740993)},
+		"name": {"a"},
+	}, "id", "name")
+	right := mustNew(t, map[string][]any{
+		"id":    {int64(9007199254740992), int64(9007199254740993)},
+		"score": {1.0, 2.0},
+	}, "id", "score")
+
+	result, err := left.Join(right, []string{"id"}, InnerJoin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, result, "id", int64(9007199254740993))
+	assertValues(t, result, "score", 2.0)
+}
@@ -0,0 +1,488 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Scanner is a data source read by a LazyTable.
+//
+// Columns returns the names of the columns of the source, in order. Scan reads the columns named in cols, which follow the order of Columns,
+// and calls emit with consecutive chunks of rows. Scan must stop and return the error returned by emit, if any.
+type Scanner interface {
+	Columns() []string
+	Scan(cols []string, emit func(chunk *Table) error) error
+}
+
+// LazyTable records operations on a data source into a plan, and executes it on Collect.
+//
+// Before execution the plan is optimized:
+//   - filters are moved before the operations that do not affect them, and those reaching the source are applied to every chunk as it is scanned,
+//     or to the concatenated chunks when the types of the columns they use differ between chunks
+//   - a row limit from First is moved before the row-wise operations, and the scan stops once enough rows have been read
+//   - only the columns needed by the remaining operations are read from the source, and operations computing columns that are not used are skipped
+//
+// The result of Collect is the same as applying the operations to the complete Table in order. Errors, such as a reference to a column that does not exist, are reported by Collect.
+//
+// A LazyTable is immutable: every method returns a new LazyTable, and the original plan can be reused.
+type LazyTable struct {
+	scanner Scanner
+	steps   []lazyStep
+	err     error
+}
+
+// NewLazyTable returns a LazyTable reading from s.
+func NewLazyTable(s Scanner) *LazyTable {
+	return &LazyTable{scanner: s}
+}
+
+// Lazy returns a LazyTable reading from the Table. The Table must not be mutated until the plan has been collected.
+func (t *Table) Lazy() *LazyTable {
+	return NewLazyTable(tableScanner{table: t})
+}
+
+type tableScanner struct {
+	table *Table
+}
+
+func (s tableScanner) Columns() []string {
+	return s.table.Columns()
+}
+
+func (s tableScanner) Scan(cols []string, emit func(chunk *Table) error) error {
+	chunk, err := s.table.Select(cols...)
+	if err != nil {
+		return err
+	}
+	return emit(chunk)
+}
+
+func (l *LazyTable) with(s lazyStep) *LazyTable {
+	steps := make([]lazyStep, len(l.steps), len(l.steps)+1)
+	copy(steps, l.steps)
+
+	return &LazyTable{
+		scanner: l.scanner,
+		steps:   append(steps, s),
+		err:     l.err,
+	}
+}
+
+func (l *LazyTable) withError(err error) *LazyTable {
+	if l.err != nil {
+		return l
+	}
+	return &LazyTable{scanner: l.scanner, steps: l.steps, err: err}
+}
+
+// Select records a projection on the given columns, see Table.Select.
+func (l *LazyTable) Select(cols ...string) *LazyTable {
+	return l.with(&selectStep{cols: cols})
+}
+
+// Drop records the removal of the given columns, see Table.Drop.
+func (l *LazyTable) Drop(cols ...string) *LazyTable {
+	return l.with(&dropStep{cols: cols})
+}
+
+// Filter records a filter keeping the rows for which the boolean expression is true, see Table.Filter.
+func (l *LazyTable) Filter(expression string) *LazyTable {
+	e, err := ParseExpr(expression)
+	if err != nil {
+		return l.withError(err)
+	}
+	return l.FilterExpr(e)
+}
+
+// FilterExpr is like Filter, for an expression parsed with ParseExpr.
+func (l *LazyTable) FilterExpr(e *Expr) *LazyTable {
+	return l.with(&filterStep{expr: e})
+}
+
+// Where records a filter using a predicate function, see Table.Where.
+//
+// Since the predicate may look at any column, Where needs every column available at its position in the plan, and filters after it are not moved before it.
+func (l *LazyTable) Where(f func(row map[string]any) bool) *LazyTable {
+	return l.with(&whereStep{f: f})
+}
+
+// MapCol records the transformation of the values of a column, see Table.MapCol.
+func (l *LazyTable) MapCol(name string, f func(any) any) *LazyTable {
+	return l.with(&mapColStep{name: name, f: f})
+}
+
+// WithColumn records a column computed from an expression, see Table.WithColumn.
+func (l *LazyTable) WithColumn(name, expression string) *LazyTable {
+	e, err := ParseExpr(expression)
+	if err != nil {
+		return l.withError(err)
+	}
+	return l.WithColumnExpr(name, e)
+}
+
+// WithColumnExpr is like WithColumn, for an expression parsed with ParseExpr.
+func (l *LazyTable) WithColumnExpr(name string, e *Expr) *LazyTable {
+	return l.with(&withColumnStep{name: name, expr: e})
+}
+
+// RenameColumn records the renaming of a column, see Table.RenameColumn.
+func (l *LazyTable) RenameColumn(oldName, newName string) *LazyTable {
+	return l.with(&renameStep{oldName: oldName, newName: newName})
+}
+
+// Categorize records the encoding of categorical columns, see Table.Categorize.
+//
+// Whether a column is categorical depends on all of its values, so filters and row limits are never moved before Categorize.
+// For the same reason the "<column>_categorized" columns it adds are only known once the plan is collected: they are part of the result of Collect,
+// but the operations recorded after Categorize can not refer to them, and Collect returns an error if they do.
+func (l *LazyTable) Categorize() *LazyTable {
+	return l.with(&categorizeStep{})
+}
+
+// SortBy records a sort by the given keys, see Table.SortBy.
+func (l *LazyTable) SortBy(keys ...SortKey) *LazyTable {
+	return l.with(&sortStep{keys: keys})
+}
+
+// First records a limit to the first n rows, see Table.First. If n is not provided, the default number of rows is 5.
+func (l *LazyTable) First(n ...int) *LazyTable {
+	rows := defaultDisplayRows
+	if len(n) > 0 {
+		rows = n[0]
+	}
+	return l.with(&firstStep{n: max(rows, 0)})
+}
+
+// Explain returns a description of the optimized plan, one operation per line, starting with the last operation and ending with the scan of the source.
+//
+// An error is returned if the plan is invalid.
+func (l *LazyTable) Explain() (string, error) {
+	p, err := l.optimize()
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{}
+	for i := len(p.steps) - 1; i >= 0; i-- {
+		lines = append(lines, p.steps[i].String())
+	}
+	lines = append(lines, p.String())
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// Collect optimizes and executes the plan, and returns the resulting Table.
+//
+// An error is returned if the plan is invalid, if reading the source fails, or if any operation fails.
+func (l *LazyTable) Collect() (*Table, error) {
+	p, err := l.optimize()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := p.scan(l.scanner)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range p.steps {
+		if t, err = s.apply(t); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// lazyPlan is an optimized plan: the scan of the source, with the filters and the row limit applied while scanning, followed by the other operations.
+type lazyPlan struct {
+	columns []string
+	filters []*Expr
+	limit   int // -1 when there is no limit
+	steps   []lazyStep
+}
+
+// errStopScan is returned to a Scanner to stop scanning once the row limit is reached.
+var errStopScan = errors.New("lazy: stop scan")
+
+// scan reads the source, applying the filters and the row limit to every chunk.
+//
+// A filter is type-checked against the column types of each chunk, which may differ from the types of the complete Table when a column holds integers in
+// some chunks and text in others. When the types of the filter columns change between chunks, or a filter fails on a chunk, the remaining chunks are kept
+// unfiltered, and the filters and the row limit are applied once every chunk has been concatenated, as they would be on the complete Table.
+func (p *lazyPlan) scan(s Scanner) (*Table, error) {
+	if p.limit == 0 {
+		return emptyTable(p.columns), nil
+	}
+
+	chunks := []*Table{}
+	rows := 0
+	types := map[string]Type{}
+	deferred := false
+
+	err := s.Scan(p.columns, func(chunk *Table) error {
+		if !deferred && !p.sameTypes(chunk, types) {
+			deferred = true
+		}
+
+		if !deferred {
+			filtered, err := filterChunk(chunk, p.filters)
+			if err != nil {
+				deferred = true
+			} else {
+				chunk = filtered
+			}
+		}
+
+		if deferred {
+			chunks = append(chunks, chunk)
+			return nil
+		}
+
+		if p.limit >= 0 && rows+chunk.length >= p.limit {
+			chunks = append(chunks, chunk.First(p.limit-rows))
+			return errStopScan
+		}
+
+		chunks = append(chunks, chunk)
+		rows += chunk.length
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopScan) {
+		return nil, err
+	}
+
+	var t *Table
+	switch len(chunks) {
+	case 0:
+		return emptyTable(p.columns), nil
+	case 1:
+		t = chunks[0]
+	default:
+		if t, err = Concat(chunks...); err != nil {
+			return nil, err
+		}
+	}
+
+	if !deferred {
+		return t, nil
+	}
+
+	if t, err = filterChunk(t, p.filters); err != nil {
+		return nil, err
+	}
+	if p.limit >= 0 {
+		t = t.First(p.limit)
+	}
+	return t, nil
+}
+
+func filterChunk(t *Table, filters []*Expr) (*Table, error) {
+	for _, f := range filters {
+		var err error
+		if t, err = t.FilterExpr(f); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// sameTypes reports whether the filter columns of chunk have the types recorded in types, and records the types of columns seen for the first time.
+// Columns holding only missing values are ignored.
+func (p *lazyPlan) sameTypes(chunk *Table, types map[string]Type) bool {
+	for _, f := range p.filters {
+		for _, c := range f.Columns() {
+			v := chunk.data[c]
+			if allNull(v) {
+				continue
+			}
+
+			typ, ok := types[c]
+			if !ok {
+				types[c] = v.Type()
+				continue
+			}
+			if typ != v.Type() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func allNull(v vector) bool {
+	for i := 0; i < v.Len(); i++ {
+		if !v.IsNull(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// emptyTable returns a Table with the given columns and no rows.
+func emptyTable(columns []string) *Table {
+	data := make(map[string]vector, len(columns))
+	for _, c := range columns {
+		data[c] = newVector(nil)
+	}
+
+	return &Table{
+		columns: append([]string{}, columns...),
+		data:    data,
+	}
+}
+
+func (p *lazyPlan) String() string {
+	s := "scan [" + strings.Join(p.columns, ", ") + "]"
+	for _, f := range p.filters {
+		s += " filter " + f.String()
+	}
+	if p.limit >= 0 {
+		s += fmt.Sprintf(" limit %d", p.limit)
+	}
+	return s
+}
+
+// optimize checks the plan against the columns of the source, and returns the optimized plan.
+func (l *LazyTable) optimize() (*lazyPlan, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	source := l.scanner.Columns()
+	if _, err := schemas(source, l.steps); err != nil {
+		return nil, err
+	}
+
+	steps := pushDown(l.steps)
+	p := &lazyPlan{limit: -1}
+
+	for len(steps) > 0 {
+		f, ok := steps[0].(*filterStep)
+		if !ok {
+			break
+		}
+		p.filters = append(p.filters, f.expr)
+		steps = steps[1:]
+	}
+
+	if len(steps) > 0 {
+		if f, ok := steps[0].(*firstStep); ok {
+			p.limit = f.n
+			steps = steps[1:]
+		}
+	}
+
+	ins, err := schemas(source, steps)
+	if err != nil {
+		return nil, err
+	}
+
+	need := columnSet(ins[len(steps)])
+	kept := []lazyStep{}
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		needIn, keep := steps[i].required(ins[i], need)
+		if keep {
+			kept = append([]lazyStep{steps[i]}, kept...)
+		}
+		need = nonEmpty(needIn, ins[i])
+	}
+
+	for _, f := range p.filters {
+		for _, c := range f.Columns() {
+			need[c] = true
+		}
+	}
+
+	for _, c := range source {
+		if need[c] {
+			p.columns = append(p.columns, c)
+		}
+	}
+	if len(p.columns) == 0 && len(source) > 0 {
+		p.columns = source[:1]
+	}
+
+	p.steps = kept
+	return p, nil
+}
+
+// schemas returns the columns of the input of every step, followed by the columns of the output of the last step.
+func schemas(source []string, steps []lazyStep) ([][]string, error) {
+	ins := make([][]string, 0, len(steps)+1)
+	cols := source
+	categorized := false
+
+	for _, s := range steps {
+		ins = append(ins, cols)
+
+		var err error
+		if cols, err = s.schema(cols); err != nil {
+			if categorized {
+				return nil, fmt.Errorf("%w (columns added by Categorize are only known once the plan is collected)", err)
+			}
+			return nil, err
+		}
+
+		if _, ok := s.(*categorizeStep); ok {
+			categorized = true
+		}
+	}
+
+	return append(ins, cols), nil
+}
+
+// pushDown moves filters and row limits before the operations they can be swapped with, keeping their relative order.
+func pushDown(steps []lazyStep) []lazyStep {
+	result := make([]lazyStep, len(steps))
+	copy(result, steps)
+
+	for i := 1; i < len(result); i++ {
+		for j := i; j > 0 && canSwap(result[j-1], result[j]); j-- {
+			result[j-1], result[j] = result[j], result[j-1]
+		}
+	}
+
+	return result
+}
+
+// canSwap reports whether step s can be executed before prev with the same result.
+func canSwap(prev, s lazyStep) bool {
+	switch s := s.(type) {
+	case *filterStep:
+		cols := s.expr.Columns()
+		switch prev := prev.(type) {
+		case *selectStep, *dropStep, *sortStep:
+			return true
+		case *mapColStep:
+			return !containsColumn(cols, prev.name)
+		case *withColumnStep:
+			return !containsColumn(cols, prev.name)
+		case *renameStep:
+			return !containsColumn(cols, prev.newName)
+		}
+	case *firstStep:
+		switch prev.(type) {
+		case *selectStep, *dropStep, *mapColStep, *withColumnStep, *renameStep:
+			return true
+		}
+	}
+	return false
+}
+
+func columnSet(cols []string) map[string]bool {
+	set := make(map[string]bool, len(cols))
+	for _, c := range cols {
+		set[c] = true
+	}
+	return set
+}
+
+// nonEmpty returns need, adding the first input column when need is empty, so that the number of rows is preserved.
+func nonEmpty(need map[string]bool, in []string) map[string]bool {
+	if len(need) == 0 && len(in) > 0 {
+		need[in[0]] = true
+	}
+	return need
+}
@@ -0,0 +1,351 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lazyStep is an operation recorded in a LazyTable.
+type lazyStep interface {
+	// schema returns the output columns for the given input columns, and checks that the columns the step refers to exist.
+	schema(in []string) ([]string, error)
+	// required returns the input columns needed to produce the output columns in need, and whether the step is needed at all.
+	// It may modify need.
+	required(in []string, need map[string]bool) (map[string]bool, bool)
+	// apply executes the step. Input columns that are not needed may be missing.
+	apply(t *Table) (*Table, error)
+	String() string
+}
+
+type selectStep struct {
+	cols []string
+}
+
+func (s *selectStep) schema(in []string) ([]string, error) {
+	if len(s.cols) == 0 {
+		return nil, fmt.Errorf("select: no columns specified")
+	}
+	if err := checkColumns("select", in, s.cols); err != nil {
+		return nil, err
+	}
+	return s.cols, nil
+}
+
+func (s *selectStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	needIn := map[string]bool{}
+	for _, c := range s.cols {
+		if need[c] {
+			needIn[c] = true
+		}
+	}
+	return needIn, true
+}
+
+func (s *selectStep) apply(t *Table) (*Table, error) {
+	return t.Select(present(t, s.cols)...)
+}
+
+func (s *selectStep) String() string {
+	return "select " + strings.Join(s.cols, ", ")
+}
+
+type dropStep struct {
+	cols []string
+}
+
+func (s *dropStep) schema(in []string) ([]string, error) {
+	if err := checkColumns("drop", in, s.cols); err != nil {
+		return nil, err
+	}
+
+	out := []string{}
+	for _, c := range in {
+		if !containsColumn(s.cols, c) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (s *dropStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	return need, true
+}
+
+func (s *dropStep) apply(t *Table) (*Table, error) {
+	cols := present(t, s.cols)
+	if len(cols) == 0 {
+		return t, nil
+	}
+	return t.Drop(cols...)
+}
+
+func (s *dropStep) String() string {
+	return "drop " + strings.Join(s.cols, ", ")
+}
+
+type filterStep struct {
+	expr *Expr
+}
+
+func (s *filterStep) schema(in []string) ([]string, error) {
+	if err := checkExprColumns(in, s.expr); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+func (s *filterStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	for _, c := range s.expr.Columns() {
+		need[c] = true
+	}
+	return need, true
+}
+
+func (s *filterStep) apply(t *Table) (*Table, error) {
+	return t.FilterExpr(s.expr)
+}
+
+func (s *filterStep) String() string {
+	return "filter " + s.expr.String()
+}
+
+type whereStep struct {
+	f func(row map[string]any) bool
+}
+
+func (s *whereStep) schema(in []string) ([]string, error) {
+	return in, nil
+}
+
+func (s *whereStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	return columnSet(in), true
+}
+
+func (s *whereStep) apply(t *Table) (*Table, error) {
+	return t.Where(s.f)
+}
+
+func (s *whereStep) String() string {
+	return "where <func>"
+}
+
+type mapColStep struct {
+	name string
+	f    func(any) any
+}
+
+func (s *mapColStep) schema(in []string) ([]string, error) {
+	if !containsColumn(in, s.name) {
+		return nil, fmt.Errorf("column %s not found", s.name)
+	}
+	return in, nil
+}
+
+func (s *mapColStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	return need, need[s.name]
+}
+
+func (s *mapColStep) apply(t *Table) (*Table, error) {
+	return t.MapCol(s.name, s.f)
+}
+
+func (s *mapColStep) String() string {
+	return "map " + s.name
+}
+
+type withColumnStep struct {
+	name string
+	expr *Expr
+}
+
+func (s *withColumnStep) schema(in []string) ([]string, error) {
+	if s.name == "" {
+		return nil, fmt.Errorf("with column: column name can not be empty")
+	}
+	if err := checkExprColumns(in, s.expr); err != nil {
+		return nil, err
+	}
+
+	if containsColumn(in, s.name) {
+		return in, nil
+	}
+	return append(in[:len(in):len(in)], s.name), nil
+}
+
+// required keeps a replaced column in the input, so that the result takes its place.
+func (s *withColumnStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	if !need[s.name] {
+		return need, false
+	}
+
+	if !containsColumn(in, s.name) {
+		delete(need, s.name)
+	}
+	for _, c := range s.expr.Columns() {
+		need[c] = true
+	}
+	return need, true
+}
+
+func (s *withColumnStep) apply(t *Table) (*Table, error) {
+	return t.WithColumnExpr(s.name, s.expr)
+}
+
+func (s *withColumnStep) String() string {
+	return "with column " + s.name + " = " + s.expr.String()
+}
+
+type renameStep struct {
+	oldName string
+	newName string
+}
+
+func (s *renameStep) schema(in []string) ([]string, error) {
+	if !containsColumn(in, s.oldName) {
+		return nil, fmt.Errorf("rename: column %s does not exist", s.oldName)
+	}
+	if s.newName != s.oldName && containsColumn(in, s.newName) {
+		return nil, fmt.Errorf("rename: column %s already exists", s.newName)
+	}
+
+	out := make([]string, len(in))
+	for i, c := range in {
+		if c == s.oldName {
+			c = s.newName
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+func (s *renameStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	if !need[s.newName] {
+		return need, false
+	}
+
+	delete(need, s.newName)
+	need[s.oldName] = true
+	return need, true
+}
+
+func (s *renameStep) apply(t *Table) (*Table, error) {
+	result := t.copy()
+	if err := result.RenameColumn(s.oldName, s.newName); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *renameStep) String() string {
+	return "rename " + s.oldName + " to " + s.newName
+}
+
+// categorizeStep may append a "<column>_categorized" column after every column, depending on its values.
+//
+// Which columns are added is only known once the step is executed, so its schema only holds its input columns,
+// and it requires every input column, since any of them may add a column to the result.
+type categorizeStep struct{}
+
+func (s *categorizeStep) schema(in []string) ([]string, error) {
+	return in, nil
+}
+
+func (s *categorizeStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	return columnSet(in), true
+}
+
+func (s *categorizeStep) apply(t *Table) (*Table, error) {
+	return t.Categorize(), nil
+}
+
+func (s *categorizeStep) String() string {
+	return "categorize"
+}
+
+type sortStep struct {
+	keys []SortKey
+}
+
+func (s *sortStep) schema(in []string) ([]string, error) {
+	if len(s.keys) == 0 {
+		return nil, fmt.Errorf("sort: no keys specified")
+	}
+	for _, k := range s.keys {
+		if !containsColumn(in, k.Column) {
+			return nil, fmt.Errorf("sort: column %s does not exist", k.Column)
+		}
+	}
+	return in, nil
+}
+
+func (s *sortStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	for _, k := range s.keys {
+		need[k.Column] = true
+	}
+	return need, true
+}
+
+func (s *sortStep) apply(t *Table) (*Table, error) {
+	return t.SortBy(s.keys...)
+}
+
+func (s *sortStep) String() string {
+	keys := make([]string, len(s.keys))
+	for i, k := range s.keys {
+		keys[i] = k.Column
+		if k.Descending {
+			keys[i] += " desc"
+		}
+	}
+	return "sort " + strings.Join(keys, ", ")
+}
+
+type firstStep struct {
+	n int
+}
+
+func (s *firstStep) schema(in []string) ([]string, error) {
+	return in, nil
+}
+
+func (s *firstStep) required(in []string, need map[string]bool) (map[string]bool, bool) {
+	return need, true
+}
+
+func (s *firstStep) apply(t *Table) (*Table, error) {
+	return t.First(s.n), nil
+}
+
+func (s *firstStep) String() string {
+	return fmt.Sprintf("first %d", s.n)
+}
+
+// checkColumns returns an error prefixed with op if any of cols is not one of the columns in.
+func checkColumns(op string, in, cols []string) error {
+	for _, c := range cols {
+		if !containsColumn(in, c) {
+			return fmt.Errorf("%s: column %s does not exist", op, c)
+		}
+	}
+	return nil
+}
+
+func checkExprColumns(in []string, e *Expr) error {
+	for _, c := range e.Columns() {
+		if !containsColumn(in, c) {
+			return fmt.Errorf("expr: column %s not found", c)
+		}
+	}
+	return nil
+}
+
+// present returns the columns of cols that t holds, in the order of cols.
+func present(t *Table, cols []string) []string {
+	result := make([]string, 0, len(cols))
+	for _, c := range cols {
+		if _, ok := t.data[c]; ok {
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,349 @@
+package table
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// chunkScanner emits its chunks one after the other, selecting the requested columns.
+type chunkScanner struct {
+	chunks []*Table
+	scans  int
+}
+
+func (s *chunkScanner) Columns() []string {
+	return s.chunks[0].Columns()
+}
+
+func (s *chunkScanner) Scan(cols []string, emit func(chunk *Table) error) error {
+	for _, c := range s.chunks {
+		s.scans++
+		chunk, err := c.Select(cols...)
+		if err != nil {
+			return err
+		}
+		if err := emit(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitRows returns the rows of t in chunks of n rows.
+func splitRows(t *Table, n int) []*Table {
+	chunks := []*Table{}
+	for from := 0; from < t.length; from += n {
+		indexes := []int{}
+		for i := from; i < min(from+n, t.length); i++ {
+			indexes = append(indexes, i)
+		}
+		chunks = append(chunks, t.fetchRows(indexes))
+	}
+	return chunks
+}
+
+func assertSameTable(tb testing.TB, got, want *Table) {
+	tb.Helper()
+
+	assertColumns(tb, got, want.Columns()...)
+	for _, c := range want.Columns() {
+		assertValues(tb, got, c, want.MustCol(c).Values()...)
+	}
+}
+
+func lazyTable(tb testing.TB) *Table {
+	return mustNew(tb, map[string][]any{
+		"id":      {int64(1), int64(2), int64(3), int64(4), int64(5), int64(6), int64(7)},
+		"country": {"ID", "NO", "ID", "SE", "ID", "NO", nil},
+		"price":   {10.0, 20.0, 30.0, nil, 50.0, 60.0, 70.0},
+		"qty":     {int64(1), int64(2), int64(3), int64(4), int64(5), int64(6), int64(7)},
+	}, "id", "country", "price", "qty")
+}
+
+func TestLazyMatchesEager(t *testing.T) {
+	tbl := lazyTable(t)
+
+	tests := []struct {
+		name  string
+		lazy  func(*LazyTable) *LazyTable
+		eager func(*Table) (*Table, error)
+	}{
+		{
+			name: "filter and select",
+			lazy: func(l *LazyTable) *LazyTable { return l.Select("id", "country").Filter("country == 'ID'") },
+			eager: func(t *Table) (*Table, error) {
+				t, err := t.Select("id", "country")
+				if err != nil {
+					return nil, err
+				}
+				return t.Filter("country == 'ID'")
+			},
+		},
+		{
+			name: "computed column, sort and limit",
+			lazy: func(l *LazyTable) *LazyTable {
+				return l.WithColumn("total", "price * qty").Filter("total > 20").SortBy(Desc("total")).First(2).Select("id", "total")
+			},
+			eager: func(t *Table) (*Table, error) {
+				t, err := t.WithColumn("total", "price * qty")
+				if err != nil {
+					return nil, err
+				}
+				if t, err = t.Filter("total > 20"); err != nil {
+					return nil, err
+				}
+				if t, err = t.SortBy(Desc("total")); err != nil {
+					return nil, err
+				}
+				return t.First(2).Select("id", "total")
+			},
+		},
+		{
+			name: "rename and drop",
+			lazy: func(l *LazyTable) *LazyTable {
+				return l.RenameColumn("qty", "quantity").Filter("quantity >= 3").Drop("price").First(3)
+			},
+			eager: func(t *Table) (*Table, error) {
+				t = t.copy()
+				if err := t.RenameColumn("qty", "quantity"); err != nil {
+					return nil, err
+				}
+				t, err := t.Filter("quantity >= 3")
+				if err != nil {
+					return nil, err
+				}
+				if t, err = t.Drop("price"); err != nil {
+					return nil, err
+				}
+				return t.First(3), nil
+			},
+		},
+		{
+			name: "map column",
+			lazy: func(l *LazyTable) *LazyTable {
+				return l.MapCol("country", func(v any) any { return v == "ID" }).Filter("country == true")
+			},
+			eager: func(t *Table) (*Table, error) {
+				t, err := t.MapCol("country", func(v any) any { return v == "ID" })
+				if err != nil {
+					return nil, err
+				}
+				return t.Filter("country == true")
+			},
+		},
+		{
+			name: "where",
+			lazy: func(l *LazyTable) *LazyTable {
+				return l.Where(func(row map[string]any) bool { return row["qty"] != int64(2) }).Filter("price is null or price < 60")
+			},
+			eager: func(t *Table) (*Table, error) {
+				t, err := t.Where(func(row map[string]any) bool { return row["qty"] != int64(2) })
+				if err != nil {
+					return nil, err
+				}
+				return t.Filter("price is null or price < 60")
+			},
+		},
+		{
+			name: "categorize and drop",
+			lazy: func(l *LazyTable) *LazyTable { return l.Categorize().Drop("price") },
+			eager: func(t *Table) (*Table, error) {
+				return t.Categorize().Drop("price")
+			},
+		},
+		{
+			name:  "limit zero",
+			lazy:  func(l *LazyTable) *LazyTable { return l.First(0) },
+			eager: func(t *Table) (*Table, error) { return t.First(0), nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := tt.eager(tbl)
+			if err != nil {
+				t.Fatalf("eager: %v", err)
+			}
+
+			for _, n := range []int{1, 3, 7} {
+				got, err := tt.lazy(NewLazyTable(&chunkScanner{chunks: splitRows(tbl, n)})).Collect()
+				if err != nil {
+					t.Fatalf("Collect with chunks of %d rows: %v", n, err)
+				}
+				assertSameTable(t, got, want)
+			}
+		})
+	}
+}
+
+func TestLazyFilterAcrossChunkTypes(t *testing.T) {
+	ints := mustNew(t, map[string][]any{"zip": {int64(1234), int64(5678)}, "n": {int64(1), int64(2)}}, "zip", "n")
+	empty := mustNew(t, map[string][]any{"zip": {nil}, "n": {int64(3)}}, "zip", "n")
+	texts := mustNew(t, map[string][]any{"zip": {"01234A", "5678"}, "n": {int64(4), int64(5)}}, "zip", "n")
+
+	whole, err := Concat(ints, empty, texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filter string
+		limit  int
+		n      []any
+	}{
+		{"zip == '01234A'", -1, []any{int64(4)}},
+		{"zip == 5678", -1, []any{int64(2)}},
+		{"zip is null or n > 3", -1, []any{int64(3), int64(4), int64(5)}},
+		{"n != 1", 2, []any{int64(2), int64(3)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			l := NewLazyTable(&chunkScanner{chunks: []*Table{ints, empty, texts}}).Filter(tt.filter)
+			want, err := whole.Filter(tt.filter)
+			if err != nil {
+				t.Fatalf("eager: %v", err)
+			}
+			if tt.limit >= 0 {
+				l = l.First(tt.limit)
+				want = want.First(tt.limit)
+			}
+
+			got, err := l.Collect()
+			if err != nil {
+				t.Fatalf("Collect: %v", err)
+			}
+			assertSameTable(t, got, want)
+			assertValues(t, got, "n", tt.n...)
+		})
+	}
+
+	_, err = NewLazyTable(&chunkScanner{chunks: []*Table{ints, texts}}).Filter("zip > 100").Collect()
+	if err == nil || !strings.Contains(err.Error(), "cannot compare string and int") {
+		t.Fatalf("err = %v, want a comparison error", err)
+	}
+}
+
+func TestLazyLimitStopsScan(t *testing.T) {
+	s := &chunkScanner{chunks: splitRows(lazyTable(t), 2)}
+
+	got, err := NewLazyTable(s).Filter("qty > 1").First(2).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, got, "id", int64(2), int64(3))
+	if s.scans != 2 {
+		t.Errorf("scanned %d chunks, want 2", s.scans)
+	}
+}
+
+func TestLazyExplain(t *testing.T) {
+	l := lazyTable(t).Lazy()
+	keep := func(row map[string]any) bool { return true }
+
+	tests := []struct {
+		name string
+		plan *LazyTable
+		want []string
+	}{
+		{
+			name: "filter pushed to scan",
+			plan: l.WithColumn("total", "price * qty").Filter("country == 'ID'").Select("id", "total"),
+			want: []string{
+				"select id, total",
+				"with column total = price * qty",
+				"scan [id, country, price, qty] filter country == 'ID'",
+			},
+		},
+		{
+			name: "filter kept after where",
+			plan: l.Where(keep).Filter("qty > 1").Select("id"),
+			want: []string{
+				"select id",
+				"filter qty > 1",
+				"where <func>",
+				"scan [id, country, price, qty]",
+			},
+		},
+		{
+			name: "filter and limit kept after categorize",
+			plan: l.Categorize().Filter("qty > 1").First(2),
+			want: []string{
+				"first 2",
+				"filter qty > 1",
+				"categorize",
+				"scan [id, country, price, qty]",
+			},
+		},
+		{
+			name: "limit pushed to scan",
+			plan: l.Select("id", "qty").MapCol("qty", func(v any) any { return v }).First(3),
+			want: []string{
+				"map qty",
+				"select id, qty",
+				"scan [id, qty] limit 3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.plan.Explain()
+			if err != nil {
+				t.Fatalf("Explain: %v", err)
+			}
+			if want := strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Explain =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestLazyErrors(t *testing.T) {
+	l := lazyTable(t).Lazy()
+
+	tests := []struct {
+		name string
+		plan *LazyTable
+		want string
+	}{
+		{"parse error", l.Filter("qty >"), "expr:"},
+		{"missing column", l.Select("nope"), "select: column nope does not exist"},
+		{"missing filter column", l.Filter("nope > 1"), "column nope not found"},
+		{"type error", l.Filter("country > 1"), "cannot compare string and int"},
+		{"categorized column", l.Categorize().Select("country_categorized"), "only known once the plan is collected"},
+		{"no sort keys", l.SortBy(), "sort: no keys specified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.plan.Collect()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLazyIsImmutable(t *testing.T) {
+	tbl := lazyTable(t)
+	base := tbl.Lazy().Filter("qty > 2")
+
+	a, err := base.Select("id").Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := base.First(1).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertColumns(t, a, "id")
+	if a.Len() != 5 || b.Len() != 1 {
+		t.Errorf("lengths = %d and %d, want 5 and 1", a.Len(), b.Len())
+	}
+	if !reflect.DeepEqual(tbl.Columns(), []string{"id", "country", "price", "qty"}) || tbl.Len() != 7 {
+		t.Errorf("source table was modified")
+	}
+}
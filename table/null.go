@@ -0,0 +1,15 @@
+package table
+
+// IsNull reports whether v represents a missing value.
+//
+// Rowan uses nil as its single representation of missing values: every reader produces nil for empty or null cells, statistics skip nil values, Display renders them with a null marker and WriteCSV writes them as empty strings.
+func IsNull(v any) bool {
+	return v == nil
+}
+
+// IsNull reports whether the value at row index i is missing.
+//
+// IsNull panics if i is out of range.
+func (c *Column) IsNull(i int) bool {
+	return c.data.IsNull(i)
+}
@@ -0,0 +1,93 @@
+package table
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestColumnStatsSkipMissing(t *testing.T) {
+	col := NewColumn("x", []any{int64(1), nil, int64(3), nil})
+
+	tests := []struct {
+		name string
+		stat func() (float64, bool)
+		want float64
+	}{
+		{name: "sum", stat: col.Sum, want: 4},
+		{name: "mean", stat: col.Mean, want: 2},
+		{name: "min", stat: col.Min, want: 1},
+		{name: "max", stat: col.Max, want: 3},
+		{name: "median", stat: col.Median, want: 2},
+		{name: "std", stat: col.Std, want: math.Sqrt2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.stat()
+			if !ok || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %v, %v, want %v, true", got, ok, tt.want)
+			}
+		})
+	}
+
+	if col.Count() != 2 || col.Missing() != 2 {
+		t.Errorf("Count = %d, Missing = %d, want 2, 2", col.Count(), col.Missing())
+	}
+
+	if _, ok := NewColumn("x", []any{nil, nil}).Mean(); ok {
+		t.Error("Mean of a column without values: expected false")
+	}
+}
+
+func TestWriteCSVMissingValues(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"a": {int64(1), nil},
+		"b": {nil, "x"},
+	}, "a", "b")
+
+	tests := []struct {
+		name string
+		opts []WriteCSVOption
+		want string
+	}{
+		{name: "default", want: "a,b\n1,\n,x\n"},
+		{name: "marker", opts: []WriteCSVOption{WithCSVNull("NA")}, want: "a,b\n1,NA\nNA,x\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "out.csv")
+			if err := tbl.WriteCSV(path, tt.opts...); err != nil {
+				t.Fatalf("WriteCSV: %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayNullMarker(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{"a": {int64(1), nil}}, "a")
+
+	if out := captureStdout(t, func() { tbl.Display() }); !strings.Contains(out, "null") {
+		t.Errorf("Display output does not contain the default marker:\n%s", out)
+	}
+	if out := captureStdout(t, func() { tbl.Display(WithNullMarker("NaN")) }); !strings.Contains(out, "NaN") || strings.Contains(out, "null") {
+		t.Errorf("Display output does not use the marker:\n%s", out)
+	}
+}
+
+func TestIsNull(t *testing.T) {
+	if !IsNull(nil) || IsNull(0) || IsNull("") {
+		t.Error("only nil is a missing value")
+	}
+}
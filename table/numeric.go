@@ -3,11 +3,14 @@ package table
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/go-rowan/rowan/internal/numeric"
 )
 
 // NumericSlice returns a numeric column as []float64 by column index.
+//
+// Missing values are returned as NaN. An error is returned if the column contains a value that is neither numeric nor missing.
 func (t *Table) NumericSlice(columnIndex int) ([]float64, error) {
 	if columnIndex < 0 || columnIndex >= len(t.columns) {
 		return nil, fmt.Errorf("numeric slice: index out of range")
@@ -19,15 +22,42 @@ func (t *Table) NumericSlice(columnIndex int) ([]float64, error) {
 		return nil, fmt.Errorf("numeric slice: column %s not found in data", colName)
 	}
 
-	valuesCount := len(values)
+	valuesCount := values.Len()
 	if valuesCount != t.length {
 		return nil, fmt.Errorf("numeric slice: column %s has length of %d, expected %d", colName, valuesCount, t.length)
 	}
 
-	result := make([]float64, t.length)
+	return floatSlice(colName, values)
+}
 
-	for i, v := range values {
-		f, ok := numeric.ToFloat64(v)
+// floatSlice converts every value of a vector to float64, reading typed numeric vectors without boxing.
+func floatSlice(colName string, values vector) ([]float64, error) {
+	switch vec := values.(type) {
+	case *typedVector[float64]:
+		if vec.valid == nil {
+			result := make([]float64, len(vec.values))
+			copy(result, vec.values)
+			return result, nil
+		}
+	case *typedVector[int64]:
+		if vec.valid == nil {
+			return convertFloats(vec.values), nil
+		}
+	case *typedVector[int]:
+		if vec.valid == nil {
+			return convertFloats(vec.values), nil
+		}
+	}
+
+	result := make([]float64, values.Len())
+
+	for i := range result {
+		if values.IsNull(i) {
+			result[i] = math.NaN()
+			continue
+		}
+
+		f, ok := numeric.ToFloat64(values.At(i))
 		if !ok {
 			return nil, fmt.Errorf("numeric slice: column %s contains non-numeric value at row %d", colName, i)
 		}
@@ -38,7 +68,17 @@ func (t *Table) NumericSlice(columnIndex int) ([]float64, error) {
 	return result, nil
 }
 
+func convertFloats[T int | int64](values []T) []float64 {
+	result := make([]float64, len(values))
+	for i, v := range values {
+		result[i] = float64(v)
+	}
+	return result
+}
+
 // NumericMatrix returns all columns as [][]float64 (row-major).
+//
+// Missing values are returned as NaN, as done by NumericSlice.
 func (t *Table) NumericMatrix() ([][]float64, error) {
 	columnsCount := len(t.columns)
 	if columnsCount == 0 {
@@ -68,7 +108,7 @@ func (t *Table) NumericMatrix() ([][]float64, error) {
 //
 // This method panics if:
 //   - columnIndex is out of range
-//   - any value in the column cannot be converted to float64
+//   - any non-missing value in the column cannot be converted to float64
 //
 // MustNumericSlice assumes that the Table was constructed correctly:
 //   - all columns exist in the table's data map
@@ -82,17 +122,10 @@ func (t *Table) MustNumericSlice(columnIndex int) []float64 {
 	}
 
 	colName := t.columns[columnIndex]
-	values, _ := t.data[colName]
-
-	result := make([]float64, t.length)
 
-	for i, v := range values {
-		f, ok := numeric.ToFloat64(v)
-		if !ok {
-			panic(fmt.Errorf("numeric slice: column %s contains non-numeric value at row %d", colName, i))
-		}
-
-		result[i] = f
+	result, err := floatSlice(colName, t.data[colName])
+	if err != nil {
+		panic(err)
 	}
 
 	return result
@@ -118,6 +151,10 @@ func (t *Table) ColumnToIntSlice(column string) ([]int, error) {
 	intData := make([]int, len(data))
 
 	for i, val := range data {
+		if val == nil {
+			return nil, fmt.Errorf("row %d in column %s is missing", i, column)
+		}
+
 		floatVal, ok := numeric.ToFloat64(val)
 		if !ok {
 			return nil, fmt.Errorf("row %d in column %s has unsupported type: %T", i, column, val)
@@ -4,16 +4,20 @@ import "math/rand"
 
 const defaultDisplayRows = 5
 
+// fetchRows returns a new Table holding the rows at the given indexes, in order.
+//
+// When the indexes form a contiguous range, such as for First or a filter keeping every row, the columns of the new Table share the storage of the original ones.
 func (t *Table) fetchRows(indexes []int) *Table {
 	indexesCount := len(indexes)
-	data := make(map[string][]any, len(t.data))
+	data := make(map[string]vector, len(t.data))
 
+	from, contiguous := contiguousRange(indexes)
 	for col, values := range t.data {
-		colData := make([]any, 0, indexesCount)
-		for _, index := range indexes {
-			colData = append(colData, values[index])
+		if contiguous {
+			data[col] = values.slice(from, from+indexesCount)
+		} else {
+			data[col] = values.Take(indexes)
 		}
-		data[col] = colData
 	}
 
 	columns := make([]string, len(t.columns))
@@ -26,6 +30,20 @@ func (t *Table) fetchRows(indexes []int) *Table {
 	}
 }
 
+// contiguousRange reports whether indexes are consecutive increasing row indexes, and returns the first one.
+func contiguousRange(indexes []int) (int, bool) {
+	if len(indexes) == 0 || indexes[0] < 0 {
+		return 0, false
+	}
+
+	for i, index := range indexes {
+		if index != indexes[0]+i {
+			return 0, false
+		}
+	}
+	return indexes[0], true
+}
+
 func firstIndexes(n, length int) []int {
 	if n <= 0 || length <= 0 {
 		return nil
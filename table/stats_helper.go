@@ -1,5 +1,7 @@
 package table
 
+import "time"
+
 func asNumeric(v any) (float64, bool) {
 	switch n := v.(type) {
 	case int:
@@ -12,3 +14,52 @@ func asNumeric(v any) (float64, bool) {
 		return 0, false
 	}
 }
+
+// asInt returns v as an int64 if it is an int or an int64.
+func asInt(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// numericValues returns the non-missing numeric values held by v, in order.
+//
+// Typed vectors are read without boxing. For float vectors without missing values the underlying storage is returned, so callers must not modify the result.
+func numericValues(v vector) []float64 {
+	switch vec := v.(type) {
+	case *typedVector[float64]:
+		if vec.valid == nil {
+			return vec.values
+		}
+		return validValues(vec, func(x float64) float64 { return x })
+	case *typedVector[int64]:
+		return validValues(vec, func(x int64) float64 { return float64(x) })
+	case *typedVector[int]:
+		return validValues(vec, func(x int) float64 { return float64(x) })
+	case *typedVector[bool], *typedVector[string], *typedVector[time.Time]:
+		return nil
+	}
+
+	result := make([]float64, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if n, ok := asNumeric(v.At(i)); ok {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func validValues[T any](v *typedVector[T], convert func(T) float64) []float64 {
+	result := make([]float64, 0, len(v.values))
+	for i, x := range v.values {
+		if v.valid.get(i) {
+			result = append(result, convert(x))
+		}
+	}
+	return result
+}
@@ -1,11 +1,18 @@
 package table
 
+import "errors"
+
 // Table represents a simple in-memory table structure.
 // It contains the column names, the underlying data per column, and the number of rows.
+//
+// Methods that do not mutate the table, which is all of them except RenameColumn, RenameColumns, RenameColumnsFunc, ReplaceColumn and SetCol,
+// are safe for concurrent use: they only read the table and return new tables. The mutating methods must not be called while the table is used by other goroutines.
+// A table shared between goroutines can be frozen with Freeze, which makes the mutating methods return ErrFrozen instead.
 type Table struct {
 	columns []string
-	data    map[string][]any
+	data    map[string]vector
 	length  int
+	frozen  bool
 }
 
 // Columns returns a copy of the column names in their current order.
@@ -21,9 +28,12 @@ func (t *Table) Len() int {
 	return t.length
 }
 
-// Clone creates a deep copy of the table.
+// Clone creates a copy of the table.
 //
-// The cloned table has its own copy of column metadata and underlying data slices, so modifications to the returned table do not affect the original table, and vice versa.
+// The clone of a frozen table is not frozen, so Clone is the way to obtain a table that can be mutated from a frozen one.
+//
+// The cloned table has its own column metadata, so mutating methods such as RenameColumn or ReplaceColumn called on the returned table do not affect the original table, and vice versa.
+// Column data is never modified in place, so the clone shares it with the original table instead of copying it, and Clone is cheap regardless of the size of the table.
 //
 // Clone preserves:
 //   - column order
@@ -36,20 +46,25 @@ func (t *Table) Clone() *Table {
 		return nil
 	}
 
-	data := make(map[string][]any, len(t.data))
+	return t.copy()
+}
 
-	columns := make([]string, len(t.columns))
-	copy(columns, t.columns)
+// ErrFrozen is returned by the methods mutating a Table when the Table is frozen.
+var ErrFrozen = errors.New("table is frozen")
 
-	for _, c := range t.columns {
-		values := make([]any, len(t.data[c]))
-		copy(values, t.data[c])
-		data[c] = values
+// Freeze makes the Table immutable and returns it: RenameColumn, RenameColumns, RenameColumnsFunc, ReplaceColumn and SetCol return an error wrapping ErrFrozen
+// instead of modifying it, so the Table can be safely shared between goroutines.
+//
+// Freeze itself is not synchronized, and must be called before the Table is shared. A frozen Table can not be unfrozen; use Clone to obtain a mutable copy.
+// Tables returned by the methods of a frozen Table are not frozen.
+func (t *Table) Freeze() *Table {
+	if t != nil {
+		t.frozen = true
 	}
+	return t
+}
 
-	return &Table{
-		columns: columns,
-		data:    data,
-		length:  t.length,
-	}
+// Frozen reports whether the Table has been frozen with Freeze.
+func (t *Table) Frozen() bool {
+	return t != nil && t.frozen
 }
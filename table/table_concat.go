@@ -0,0 +1,260 @@
+package table
+
+import (
+	"fmt"
+	"time"
+)
+
+// Concat stacks the rows of the given tables into a new Table.
+//
+// Columns are aligned by name. The result holds every column found in any table, in order of first appearance, and rows of tables lacking a column get missing values in it.
+// When a column holds integers in some tables and float64 values in others, the integers are promoted to float64, the same way readers promote mixed numeric columns.
+// An error is returned if no tables are given or if any table is nil. The given tables are not modified.
+func Concat(tables ...*Table) (*Table, error) {
+	if err := checkConcat(tables); err != nil {
+		return nil, err
+	}
+
+	columns := []string{}
+	for _, t := range tables {
+		for _, c := range t.columns {
+			if !containsColumn(columns, c) {
+				columns = append(columns, c)
+			}
+		}
+	}
+
+	return concatTables(tables, columns), nil
+}
+
+// ConcatStrict stacks the rows of the given tables into a new Table, like Concat, but requires every table to have the same set of columns.
+//
+// Columns are aligned by name and follow the order of the first table. An error is returned if no tables are given, if any table is nil, or if a table is missing a column or has an extra one.
+func ConcatStrict(tables ...*Table) (*Table, error) {
+	if err := checkConcat(tables); err != nil {
+		return nil, err
+	}
+
+	columns := tables[0].Columns()
+	for i, t := range tables[1:] {
+		for _, c := range columns {
+			if _, ok := t.data[c]; !ok {
+				return nil, fmt.Errorf("concat: table %d is missing column %s", i+1, c)
+			}
+		}
+
+		for _, c := range t.columns {
+			if !containsColumn(columns, c) {
+				return nil, fmt.Errorf("concat: table %d has unexpected column %s", i+1, c)
+			}
+		}
+	}
+
+	return concatTables(tables, columns), nil
+}
+
+func checkConcat(tables []*Table) error {
+	if len(tables) == 0 {
+		return fmt.Errorf("concat: no tables provided")
+	}
+
+	for i, t := range tables {
+		if t == nil {
+			return fmt.Errorf("concat: table %d is nil", i)
+		}
+	}
+
+	return nil
+}
+
+func concatTables(tables []*Table, columns []string) *Table {
+	length := 0
+	lengths := make([]int, len(tables))
+	for i, t := range tables {
+		lengths[i] = t.length
+		length += t.length
+	}
+
+	data := make(map[string]vector, len(columns))
+	for _, c := range columns {
+		parts := make([]vector, len(tables))
+		for i, t := range tables {
+			parts[i] = t.data[c]
+		}
+		data[c] = concatVectors(parts, lengths)
+	}
+
+	return &Table{
+		columns: columns,
+		data:    data,
+		length:  length,
+	}
+}
+
+// AppendRow returns a new Table with row appended after the existing rows.
+//
+// Keys of row are column names; columns without a key in row get a missing value. Integer values are converted to the numeric type of their column, and a float64 value promotes an integer column to float64, see Concat.
+// An error is returned if row refers to a column that does not exist. The original Table is not modified.
+func (t *Table) AppendRow(row map[string]any) (*Table, error) {
+	return t.AppendRows([]map[string]any{row})
+}
+
+// AppendRows returns a new Table with rows appended after the existing rows, in order. See AppendRow.
+//
+// An error is returned if any row refers to a column that does not exist. The original Table is not modified.
+func (t *Table) AppendRows(rows []map[string]any) (*Table, error) {
+	for i, row := range rows {
+		for c := range row {
+			if _, ok := t.data[c]; !ok {
+				return nil, fmt.Errorf("append rows: row %d refers to column %s that does not exist", i, c)
+			}
+		}
+	}
+
+	columns := t.Columns()
+	lengths := []int{t.length, len(rows)}
+	data := make(map[string]vector, len(columns))
+
+	for _, c := range columns {
+		values := make([]any, len(rows))
+		for i, row := range rows {
+			values[i] = fillValue(t.data[c], row[c])
+		}
+
+		data[c] = concatVectors([]vector{t.data[c], newVector(values)}, lengths)
+	}
+
+	return &Table{
+		columns: columns,
+		data:    data,
+		length:  t.length + len(rows),
+	}, nil
+}
+
+// concatVectors returns the values of parts one after the other. A nil part stands for lengths[i] missing values.
+//
+// Parts sharing the same typed storage are concatenated without boxing their values.
+func concatVectors(parts []vector, lengths []int) vector {
+	var first vector
+	for _, p := range parts {
+		if p != nil && p.Len() > 0 {
+			first = p
+			break
+		}
+	}
+
+	var (
+		result vector
+		ok     bool
+	)
+
+	switch first.(type) {
+	case *typedVector[int64]:
+		result, ok = concatTyped[int64](TypeInt, parts, lengths)
+	case *typedVector[int]:
+		result, ok = concatTyped[int](TypeInt, parts, lengths)
+	case *typedVector[float64]:
+		result, ok = concatTyped[float64](TypeFloat, parts, lengths)
+	case *typedVector[bool]:
+		result, ok = concatTyped[bool](TypeBool, parts, lengths)
+	case *typedVector[string]:
+		result, ok = concatTyped[string](TypeString, parts, lengths)
+	case *typedVector[time.Time]:
+		result, ok = concatTyped[time.Time](TypeTime, parts, lengths)
+	case nil:
+		// Every part is empty or missing: keep the storage type of the first present part.
+		for _, p := range parts {
+			if p != nil {
+				return p.Take(nullIndexes(sum(lengths)))
+			}
+		}
+	}
+	if ok {
+		return result
+	}
+
+	values := make([]any, 0, sum(lengths))
+	for i, p := range parts {
+		if p == nil {
+			values = append(values, make([]any, lengths[i])...)
+			continue
+		}
+		values = append(values, p.Values()...)
+	}
+
+	if hasFloatValue(values) {
+		for i, v := range values {
+			switch n := v.(type) {
+			case int64:
+				values[i] = float64(n)
+			case int:
+				values[i] = float64(n)
+			}
+		}
+	}
+
+	return newVector(values)
+}
+
+// concatTyped concatenates parts stored as typedVector[T]. It reports false if a non-empty part has another storage type.
+func concatTyped[T any](typ Type, parts []vector, lengths []int) (vector, bool) {
+	for _, p := range parts {
+		if p == nil || p.Len() == 0 {
+			continue
+		}
+		if _, ok := p.(*typedVector[T]); !ok {
+			return nil, false
+		}
+	}
+
+	total := sum(lengths)
+	result := &typedVector[T]{
+		typ:    typ,
+		values: make([]T, 0, total),
+	}
+
+	offset := 0
+	for i, p := range parts {
+		if tv, ok := p.(*typedVector[T]); ok {
+			result.values = append(result.values, tv.values...)
+			for j := range tv.values {
+				if !tv.valid.get(j) {
+					result.valid.clear(offset+j, total)
+				}
+			}
+		} else {
+			result.values = append(result.values, make([]T, lengths[i])...)
+			for j := 0; j < lengths[i]; j++ {
+				result.valid.clear(offset+j, total)
+			}
+		}
+		offset += lengths[i]
+	}
+
+	return result, true
+}
+
+func nullIndexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = -1
+	}
+	return indexes
+}
+
+func hasFloatValue(values []any) bool {
+	for _, v := range values {
+		if _, ok := v.(float64); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,165 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	a := mustNew(t, map[string][]any{
+		"id":    {int64(1), int64(2)},
+		"score": {int64(10), nil},
+	}, "id", "score")
+	b := mustNew(t, map[string][]any{
+		"score": {2.5},
+		"name":  {"c"},
+		"id":    {int64(3)},
+	}, "score", "name", "id")
+
+	result, err := Concat(a, b)
+	if err != nil {
+		t.Fatalf("Concat: %v", err)
+	}
+
+	assertColumns(t, result, "id", "score", "name")
+	assertValues(t, result, "id", int64(1), int64(2), int64(3))
+	assertValues(t, result, "score", 10.0, nil, 2.5)
+	assertValues(t, result, "name", nil, nil, "c")
+
+	if got := result.data["id"].Type(); got != TypeInt {
+		t.Errorf("id type = %v, want %v", got, TypeInt)
+	}
+	if got := result.data["score"].Type(); got != TypeFloat {
+		t.Errorf("score type = %v, want %v", got, TypeFloat)
+	}
+
+	assertValues(t, a, "score", int64(10), nil)
+}
+
+func TestConcatKeepsTypeOfEmptyTables(t *testing.T) {
+	a := mustNew(t, map[string][]any{"name": {"x"}}, "name")
+	empty, err := a.Filter("name == 'y'")
+	if err != nil {
+		t.Fatalf("Filter: %v", err)
+	}
+
+	result, err := Concat(empty, empty)
+	if err != nil {
+		t.Fatalf("Concat: %v", err)
+	}
+	if result.Len() != 0 {
+		t.Errorf("Len = %d, want 0", result.Len())
+	}
+	if got := result.data["name"].Type(); got != TypeString {
+		t.Errorf("name type = %v, want %v", got, TypeString)
+	}
+}
+
+func TestConcatStrict(t *testing.T) {
+	a := mustNew(t, map[string][]any{"x": {int64(1)}, "y": {"a"}}, "x", "y")
+	b := mustNew(t, map[string][]any{"x": {int64(2)}, "y": {"b"}}, "y", "x")
+
+	result, err := ConcatStrict(a, b)
+	if err != nil {
+		t.Fatalf("ConcatStrict: %v", err)
+	}
+	assertColumns(t, result, "x", "y")
+	assertValues(t, result, "x", int64(1), int64(2))
+	assertValues(t, result, "y", "a", "b")
+}
+
+func TestConcatErrors(t *testing.T) {
+	a := mustNew(t, map[string][]any{"x": {int64(1)}, "y": {"a"}}, "x", "y")
+	missing := mustNew(t, map[string][]any{"x": {int64(2)}}, "x")
+	extra := mustNew(t, map[string][]any{"x": {int64(2)}, "y": {"b"}, "z": {true}}, "x", "y", "z")
+
+	tests := []struct {
+		name   string
+		concat func(...*Table) (*Table, error)
+		tables []*Table
+		want   string
+	}{
+		{"no tables", Concat, nil, "no tables provided"},
+		{"nil table", Concat, []*Table{a, nil}, "table 1 is nil"},
+		{"strict no tables", ConcatStrict, nil, "no tables provided"},
+		{"strict missing column", ConcatStrict, []*Table{a, missing}, "table 1 is missing column y"},
+		{"strict extra column", ConcatStrict, []*Table{a, extra}, "table 1 has unexpected column z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.concat(tt.tables...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendRow(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"id":    {int64(1)},
+		"score": {int64(10)},
+		"name":  {"a"},
+	}, "id", "score", "name")
+
+	tests := []struct {
+		name  string
+		row   map[string]any
+		id    []any
+		score []any
+		names []any
+	}{
+		{
+			name:  "converts int",
+			row:   map[string]any{"id": 2, "score": 20, "name": "b"},
+			id:    []any{int64(1), int64(2)},
+			score: []any{int64(10), int64(20)},
+			names: []any{"a", "b"},
+		},
+		{
+			name:  "promotes to float",
+			row:   map[string]any{"id": 2, "score": 20.5},
+			id:    []any{int64(1), int64(2)},
+			score: []any{10.0, 20.5},
+			names: []any{"a", nil},
+		},
+		{
+			name:  "empty row",
+			row:   map[string]any{},
+			id:    []any{int64(1), nil},
+			score: []any{int64(10), nil},
+			names: []any{"a", nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tbl.AppendRow(tt.row)
+			if err != nil {
+				t.Fatalf("AppendRow: %v", err)
+			}
+			assertColumns(t, result, "id", "score", "name")
+			assertValues(t, result, "id", tt.id...)
+			assertValues(t, result, "score", tt.score...)
+			assertValues(t, result, "name", tt.names...)
+		})
+	}
+
+	assertValues(t, tbl, "id", int64(1))
+}
+
+func TestAppendRows(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{"x": {int64(1)}}, "x")
+
+	result, err := tbl.AppendRows([]map[string]any{{"x": 2}, {"x": nil}, {"x": int64(4)}})
+	if err != nil {
+		t.Fatalf("AppendRows: %v", err)
+	}
+	assertValues(t, result, "x", int64(1), int64(2), nil, int64(4))
+
+	_, err = tbl.AppendRows([]map[string]any{{"x": 2}, {"y": 3}})
+	if err == nil || !strings.Contains(err.Error(), "row 1 refers to column y") {
+		t.Fatalf("err = %v, want unknown column error", err)
+	}
+}
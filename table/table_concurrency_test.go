@@ -0,0 +1,84 @@
+package table
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentReads(t *testing.T) {
+	tbl := storageTable(t).Freeze()
+
+	const goroutines = 8
+	errs := make(chan error, goroutines*2)
+
+	captureStdout(t, func() {
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				col, err := tbl.Col("x")
+				if err != nil {
+					errs <- err
+					return
+				}
+				_ = col.Values()
+
+				filtered, err := tbl.Where(func(row map[string]any) bool { return row["x"] != nil })
+				if err != nil {
+					errs <- err
+					return
+				}
+				if filtered.Len() != 133 {
+					errs <- errors.New("Where returned the wrong number of rows")
+				}
+
+				tbl.First(3).Display()
+			}()
+		}
+		wg.Wait()
+	})
+
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestFrozenTableRejectsMutations(t *testing.T) {
+	tbl := storageTable(t).Freeze()
+
+	tests := []struct {
+		name   string
+		mutate func() error
+	}{
+		{"RenameColumn", func() error { return tbl.RenameColumn("x", "y") }},
+		{"RenameColumns", func() error { return tbl.RenameColumns(map[string]string{"x": "y"}) }},
+		{"RenameColumnsFunc", func() error { return tbl.RenameColumnsFunc(func(s string) string { return s + "_" }) }},
+		{"ReplaceColumn", func() error { return tbl.ReplaceColumn("name", make([]any, 200)) }},
+		{"SetCol", func() error { return tbl.SetCol(NewColumn("name", make([]any, 200))) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.mutate(); !errors.Is(err, ErrFrozen) {
+				t.Fatalf("err = %v, want ErrFrozen", err)
+			}
+		})
+	}
+
+	assertColumns(t, tbl, "x", "name")
+	if !tbl.Frozen() {
+		t.Errorf("table is no longer frozen")
+	}
+
+	clone := tbl.Clone()
+	if clone.Frozen() {
+		t.Fatalf("clone is frozen")
+	}
+	if err := clone.RenameColumn("x", "y"); err != nil {
+		t.Fatalf("RenameColumn on the clone: %v", err)
+	}
+}
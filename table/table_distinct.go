@@ -0,0 +1,146 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Keep selects which of a set of duplicate rows DistinctBy keeps.
+type Keep int
+
+const (
+	// KeepFirst keeps the first row of every set of duplicates.
+	KeepFirst Keep = iota
+	// KeepLast keeps the last row of every set of duplicates.
+	KeepLast
+	// KeepNone drops every row that has a duplicate.
+	KeepNone
+)
+
+// Distinct returns a new Table without duplicate rows, keeping the first row of every set of duplicates.
+//
+// Rows are compared on the given columns, or on every column if none are given. Numeric values are compared by value and missing values are equal to each other.
+// The remaining rows keep their original order. An error is returned if any column does not exist. The original Table is not modified.
+func (t *Table) Distinct(cols ...string) (*Table, error) {
+	return t.DistinctBy(KeepFirst, cols...)
+}
+
+// DistinctBy returns a new Table without duplicate rows, keeping the rows selected by keep. See Distinct.
+func (t *Table) DistinctBy(keep Keep, cols ...string) (*Table, error) {
+	vectors, err := t.vectors("distinct", cols)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := []int{}
+	for _, group := range groupIndexes(vectors, t.length) {
+		switch keep {
+		case KeepFirst:
+			indexes = append(indexes, group[0])
+		case KeepLast:
+			indexes = append(indexes, group[len(group)-1])
+		case KeepNone:
+			if len(group) == 1 {
+				indexes = append(indexes, group[0])
+			}
+		default:
+			return nil, fmt.Errorf("distinct: unknown keep mode %d", keep)
+		}
+	}
+	slices.Sort(indexes)
+
+	return t.fetchRows(indexes), nil
+}
+
+// Duplicated returns a bool Column named "duplicated" that is true for every row equal to an earlier row.
+//
+// Rows are compared on the given columns, or on every column if none are given, the same way as Distinct. An error is returned if any column does not exist.
+func (t *Table) Duplicated(cols ...string) (*Column, error) {
+	vectors, err := t.vectors("duplicated", cols)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := &typedVector[bool]{
+		typ:    TypeBool,
+		values: make([]bool, t.length),
+	}
+	for _, group := range groupIndexes(vectors, t.length) {
+		for _, i := range group[1:] {
+			flags.values[i] = true
+		}
+	}
+
+	return &Column{
+		name: "duplicated",
+		data: flags,
+	}, nil
+}
+
+// Unique returns a new Column holding every distinct value of the column once, in order of first appearance.
+//
+// Numeric values are compared by value, so int64(1) and float64(1) count as the same value. A missing value is kept once if present.
+func (c *Column) Unique() *Column {
+	groups := groupIndexes([]vector{c.data}, c.data.Len())
+
+	firsts := make([]int, len(groups))
+	for i, group := range groups {
+		firsts[i] = group[0]
+	}
+
+	return &Column{
+		name: c.name,
+		data: c.data.Take(firsts),
+	}
+}
+
+// NUnique returns the number of distinct non-missing values in the column.
+func (c *Column) NUnique() int {
+	n := 0
+	for _, group := range groupIndexes([]vector{c.data}, c.data.Len()) {
+		if !c.data.IsNull(group[0]) {
+			n++
+		}
+	}
+	return n
+}
+
+// ValueCounts returns a new Table with the distinct non-missing values of the column and the number of times each occurs.
+//
+// The Table has two columns, the values under the name of the column and the counts under "count". It is sorted by decreasing count, with ties in order of first appearance.
+func (c *Column) ValueCounts() *Table {
+	groups := [][]int{}
+	for _, group := range groupIndexes([]vector{c.data}, c.data.Len()) {
+		if !c.data.IsNull(group[0]) {
+			groups = append(groups, group)
+		}
+	}
+
+	slices.SortStableFunc(groups, func(a, b []int) int {
+		return len(b) - len(a)
+	})
+
+	firsts := make([]int, len(groups))
+	counts := &typedVector[int64]{
+		typ:    TypeInt,
+		values: make([]int64, len(groups)),
+	}
+	for i, group := range groups {
+		firsts[i] = group[0]
+		counts.values[i] = int64(len(group))
+	}
+
+	countName := "count"
+	if c.name == countName {
+		countName = "count_"
+	}
+
+	return &Table{
+		columns: []string{c.name, countName},
+		data: map[string]vector{
+			c.name:    c.data.Take(firsts),
+			countName: counts,
+		},
+		length: len(groups),
+	}
+}
@@ -0,0 +1,149 @@
+package table
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func distinctTable(tb testing.TB) *Table {
+	return mustNew(tb, map[string][]any{
+		"id":   {int64(1), 1.0, int64(2), nil, nil, int64(1)},
+		"name": {"a", "a", "b", nil, nil, "c"},
+	}, "id", "name")
+}
+
+func TestDistinctBy(t *testing.T) {
+	tbl := distinctTable(t)
+
+	tests := []struct {
+		name  string
+		keep  Keep
+		cols  []string
+		ids   []any
+		names []any
+	}{
+		{"first", KeepFirst, nil, []any{int64(1), int64(2), nil, int64(1)}, []any{"a", "b", nil, "c"}},
+		{"last", KeepLast, nil, []any{1.0, int64(2), nil, int64(1)}, []any{"a", "b", nil, "c"}},
+		{"none", KeepNone, nil, []any{int64(2), int64(1)}, []any{"b", "c"}},
+		{"first by id", KeepFirst, []string{"id"}, []any{int64(1), int64(2), nil}, []any{"a", "b", nil}},
+		{"last by id", KeepLast, []string{"id"}, []any{int64(2), nil, int64(1)}, []any{"b", nil, "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tbl.DistinctBy(tt.keep, tt.cols...)
+			if err != nil {
+				t.Fatalf("DistinctBy: %v", err)
+			}
+			assertColumns(t, result, "id", "name")
+			assertValues(t, result, "id", tt.ids...)
+			assertValues(t, result, "name", tt.names...)
+		})
+	}
+
+	result, err := tbl.Distinct("name")
+	if err != nil {
+		t.Fatalf("Distinct: %v", err)
+	}
+	assertValues(t, result, "name", "a", "b", nil, "c")
+	assertValues(t, tbl, "name", "a", "a", "b", nil, nil, "c")
+}
+
+func TestDuplicated(t *testing.T) {
+	tbl := distinctTable(t)
+
+	tests := []struct {
+		cols []string
+		want []any
+	}{
+		{nil, []any{false, true, false, false, true, false}},
+		{[]string{"id"}, []any{false, true, false, false, true, true}},
+	}
+
+	for _, tt := range tests {
+		col, err := tbl.Duplicated(tt.cols...)
+		if err != nil {
+			t.Fatalf("Duplicated(%v): %v", tt.cols, err)
+		}
+		if col.Name() != "duplicated" || col.Type() != TypeBool {
+			t.Errorf("Duplicated(%v) = %s of type %v, want duplicated of type %v", tt.cols, col.Name(), col.Type(), TypeBool)
+		}
+		if got := col.Values(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Duplicated(%v) = %v, want %v", tt.cols, got, tt.want)
+		}
+	}
+}
+
+func TestDistinctErrors(t *testing.T) {
+	tbl := distinctTable(t)
+
+	tests := []struct {
+		name string
+		f    func() error
+		want string
+	}{
+		{"distinct", func() error { _, err := tbl.Distinct("nope"); return err }, "distinct: column nope does not exist"},
+		{"keep mode", func() error { _, err := tbl.DistinctBy(Keep(9)); return err }, "distinct: unknown keep mode 9"},
+		{"duplicated", func() error { _, err := tbl.Duplicated("nope"); return err }, "duplicated: column nope does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.f()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueAndNUnique(t *testing.T) {
+	col := NewColumn("x", []any{int64(1), 1.0, nil, int64(2), nil})
+
+	if got, want := col.Unique().Values(), []any{int64(1), nil, int64(2)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unique = %#v, want %#v", got, want)
+	}
+	if got := col.NUnique(); got != 2 {
+		t.Errorf("NUnique = %d, want 2", got)
+	}
+}
+
+func TestValueCounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		column *Column
+		counts string
+		values []any
+		want   []any
+	}{
+		{
+			name:   "sorted by count",
+			column: NewColumn("fruit", []any{"pear", "apple", nil, "apple", "kiwi", "pear", "apple"}),
+			counts: "count",
+			values: []any{"apple", "pear", "kiwi"},
+			want:   []any{int64(3), int64(2), int64(1)},
+		},
+		{
+			name:   "column named count",
+			column: NewColumn("count", []any{int64(1), int64(1)}),
+			counts: "count_",
+			values: []any{int64(1)},
+			want:   []any{int64(2)},
+		},
+		{
+			name:   "only missing",
+			column: NewColumn("x", []any{nil, nil}),
+			counts: "count",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.column.ValueCounts()
+			assertColumns(t, result, tt.column.Name(), tt.counts)
+			assertValues(t, result, tt.column.Name(), append([]any{}, tt.values...)...)
+			assertValues(t, result, tt.counts, append([]any{}, tt.want...)...)
+		})
+	}
+}
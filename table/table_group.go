@@ -0,0 +1,236 @@
+package table
+
+import "fmt"
+
+// GroupedTable is a Table split into groups of rows sharing the same values in the key columns.
+//
+// It is created with Table.GroupBy. Groups are kept in the order of their first row in the original Table, so results are deterministic.
+type GroupedTable struct {
+	table  *Table
+	keys   []string
+	groups [][]int
+}
+
+// GroupBy splits the rows of the Table into groups sharing the same values in the given columns.
+//
+// Numeric keys are compared by value, so int64(1) and float64(1) belong to the same group. Missing values form a group of their own.
+// An error is returned if no columns are specified, if any column does not exist, or if a column is given more than once. The original Table is not modified.
+func (t *Table) GroupBy(cols ...string) (*GroupedTable, error) {
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("group by: no columns specified")
+	}
+
+	vectors := make([]vector, len(cols))
+	for k, c := range cols {
+		v, ok := t.data[c]
+		if !ok {
+			return nil, fmt.Errorf("group by: column %s does not exist", c)
+		}
+		if containsColumn(cols[:k], c) {
+			return nil, fmt.Errorf("group by: column %s specified more than once", c)
+		}
+		vectors[k] = v
+	}
+
+	keys := make([]string, len(cols))
+	copy(keys, cols)
+
+	return &GroupedTable{
+		table:  t,
+		keys:   keys,
+		groups: groupIndexes(vectors, t.length),
+	}, nil
+}
+
+// groupIndexes returns the row indexes of every group of equal keys, in order of first appearance.
+func groupIndexes(vectors []vector, length int) [][]int {
+	positions := make(map[string]int)
+	groups := [][]int{}
+
+	for i := 0; i < length; i++ {
+		key := rowKey(vectors, i)
+
+		pos, ok := positions[key]
+		if !ok {
+			pos = len(groups)
+			positions[key] = pos
+			groups = append(groups, nil)
+		}
+		groups[pos] = append(groups[pos], i)
+	}
+
+	return groups
+}
+
+// Keys returns a copy of the names of the key columns.
+func (g *GroupedTable) Keys() []string {
+	keys := make([]string, len(g.keys))
+	copy(keys, g.keys)
+	return keys
+}
+
+// Len returns the number of groups.
+func (g *GroupedTable) Len() int {
+	return len(g.groups)
+}
+
+// Agg returns a new Table with one row per group, holding the key columns followed by one column per aggregation.
+//
+// Each entry of aggs maps a column name to the aggregations applied to it. The output columns are named "<column>_<aggregation>" and appear in the column order of the original Table, then in the order of the aggregations.
+// An error is returned if aggs is empty, if any column does not exist, if an aggregation has no name or function, or if two output columns would have the same name.
+func (g *GroupedTable) Agg(aggs map[string][]AggFunc) (*Table, error) {
+	if len(aggs) == 0 {
+		return nil, fmt.Errorf("agg: no aggregations specified")
+	}
+
+	for c, funcs := range aggs {
+		if _, ok := g.table.data[c]; !ok {
+			return nil, fmt.Errorf("agg: column %s does not exist", c)
+		}
+
+		for _, f := range funcs {
+			if f.Name == "" || f.Fn == nil {
+				return nil, fmt.Errorf("agg: aggregation for column %s must have a name and a function", c)
+			}
+		}
+	}
+
+	result := g.keyTable()
+
+	for _, c := range g.table.columns {
+		for _, f := range aggs[c] {
+			name := c + "_" + f.Name
+			if _, exists := result.data[name]; exists {
+				return nil, fmt.Errorf("agg: duplicate output column %s", name)
+			}
+
+			result.data[name] = newVector(g.aggregate(c, f))
+			result.columns = append(result.columns, name)
+		}
+	}
+
+	return result, nil
+}
+
+// Count returns a new Table with one row per group, holding the key columns followed by the number of non-missing values of every other column.
+func (g *GroupedTable) Count() *Table {
+	result := g.keyTable()
+
+	for _, c := range g.table.columns {
+		if containsColumn(g.keys, c) {
+			continue
+		}
+
+		result.data[c] = newVector(g.aggregate(c, AggCount))
+		result.columns = append(result.columns, c)
+	}
+
+	return result
+}
+
+// Size returns a new Table with one row per group, holding the key columns followed by a "size" column with the number of rows in the group.
+func (g *GroupedTable) Size() *Table {
+	result := g.keyTable()
+
+	sizes := make([]any, len(g.groups))
+	for i, group := range g.groups {
+		sizes[i] = int64(len(group))
+	}
+
+	name := "size"
+	if _, exists := result.data[name]; exists {
+		name = "size_"
+	}
+
+	result.data[name] = newVector(sizes)
+	result.columns = append(result.columns, name)
+
+	return result
+}
+
+// keyTable returns a Table with one row per group holding the values of the key columns.
+func (g *GroupedTable) keyTable() *Table {
+	firsts := make([]int, len(g.groups))
+	for i, group := range g.groups {
+		firsts[i] = group[0]
+	}
+
+	data := make(map[string]vector, len(g.keys))
+	columns := make([]string, len(g.keys))
+	copy(columns, g.keys)
+
+	for _, c := range g.keys {
+		data[c] = g.table.data[c].Take(firsts)
+	}
+
+	return &Table{
+		columns: columns,
+		data:    data,
+		length:  len(g.groups),
+	}
+}
+
+// aggregate applies f to the values of column c in every group.
+func (g *GroupedTable) aggregate(c string, f AggFunc) []any {
+	values := make([]any, len(g.groups))
+	for i, group := range g.groups {
+		values[i] = f.Fn(&Column{
+			name: c,
+			data: g.table.data[c].Take(group),
+		})
+	}
+	return values
+}
+
+// Transform applies f to the values of column col within every group, and returns a new Table holding the results in a column called name, aligned with the original rows.
+//
+// This is how window operations are partitioned by key, for example the previous price of the same product:
+//
+//	g.Transform("price", "prev_price", func(c *table.Column) (*table.Column, error) {
+//		return c.Lag(1), nil
+//	})
+//
+// Rows are passed to f in their original order. If name is an existing column it is replaced in place, otherwise the column is appended.
+// An error is returned if col does not exist, if f fails, or if f returns a column whose length differs from the group. The original Table is not modified.
+func (g *GroupedTable) Transform(col, name string, f func(*Column) (*Column, error)) (*Table, error) {
+	source, ok := g.table.data[col]
+	if !ok {
+		return nil, fmt.Errorf("transform: column %s does not exist", col)
+	}
+
+	parts := make([]vector, len(g.groups))
+	lengths := make([]int, len(g.groups))
+	positions := make([]int, g.table.length)
+	offset := 0
+
+	for k, group := range g.groups {
+		result, err := f(&Column{name: col, data: source.Take(group)})
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil {
+			return nil, fmt.Errorf("transform: function returned no column")
+		}
+		if result.data.Len() != len(group) {
+			return nil, fmt.Errorf("transform: function returned %d values for a group of %d rows", result.data.Len(), len(group))
+		}
+
+		parts[k] = result.data
+		lengths[k] = len(group)
+		for j, i := range group {
+			positions[i] = offset + j
+		}
+		offset += len(group)
+	}
+
+	values := concatVectors(parts, lengths).Take(positions)
+
+	result := g.table.copy()
+	if _, exists := result.data[name]; !exists {
+		result.columns = append(result.columns, name)
+	}
+	result.data[name] = values
+
+	return result, nil
+}
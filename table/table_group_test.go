@@ -0,0 +1,122 @@
+package table
+
+import (
+	"testing"
+)
+
+func salesTable(tb testing.TB) *Table {
+	return mustNew(tb, map[string][]any{
+		"region": {"west", "east", "west", "east", nil},
+		"year":   {int64(2023), int64(2023), 2023.0, int64(2024), int64(2024)},
+		"amount": {10.0, 20.0, 30.0, nil, 5.0},
+	}, "region", "year", "amount")
+}
+
+func TestGroupByAgg(t *testing.T) {
+	g, err := salesTable(t).GroupBy("region")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", g.Len())
+	}
+
+	result, err := g.Agg(map[string][]AggFunc{
+		"amount": {AggSum, AggMean, AggCount, AggFirst},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertColumns(t, result, "region", "amount_sum", "amount_mean", "amount_count", "amount_first")
+	assertValues(t, result, "region", "west", "east", nil)
+	assertValues(t, result, "amount_sum", 40.0, 20.0, 5.0)
+	assertValues(t, result, "amount_mean", 20.0, 20.0, 5.0)
+	assertValues(t, result, "amount_count", int64(2), int64(1), int64(1))
+	assertValues(t, result, "amount_first", 10.0, 20.0, 5.0)
+}
+
+func TestGroupByNumericKeys(t *testing.T) {
+	g, err := salesTable(t).GroupBy("year")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	size := g.Size()
+	assertColumns(t, size, "year", "size")
+	assertValues(t, size, "size", int64(3), int64(2))
+}
+
+func TestGroupByMultipleKeys(t *testing.T) {
+	g, err := salesTable(t).GroupBy("region", "year")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := g.Count()
+	assertColumns(t, count, "region", "year", "amount")
+	assertValues(t, count, "region", "west", "east", "east", nil)
+	assertValues(t, count, "amount", int64(2), int64(1), int64(0), int64(1))
+}
+
+func TestGroupByCustomAgg(t *testing.T) {
+	g, err := salesTable(t).GroupBy("region")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	span := AggFunc{Name: "span", Fn: func(c *Column) any {
+		lo, _ := c.Min()
+		hi, ok := c.Max()
+		if !ok {
+			return nil
+		}
+		return hi - lo
+	}}
+
+	result, err := g.Agg(map[string][]AggFunc{"amount": {span, AggQuantile(0.5)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertColumns(t, result, "region", "amount_span", "amount_q0.5")
+	assertValues(t, result, "amount_span", 20.0, 0.0, 0.0)
+}
+
+func TestGroupByErrors(t *testing.T) {
+	tbl := salesTable(t)
+
+	tests := []struct {
+		name string
+		cols []string
+	}{
+		{name: "no columns"},
+		{name: "missing column", cols: []string{"missing"}},
+		{name: "duplicate column", cols: []string{"region", "region"}},
+		{name: "duplicate column among others", cols: []string{"region", "year", "region"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tbl.GroupBy(tt.cols...); err == nil {
+				t.Error("GroupBy: expected an error")
+			}
+		})
+	}
+
+	g, err := tbl.GroupBy("region")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aggErrors := []map[string][]AggFunc{
+		{},
+		{"missing": {AggSum}},
+		{"amount": {{Name: "bad"}}},
+		{"amount": {AggSum, AggSum}},
+	}
+	for _, aggs := range aggErrors {
+		if _, err := g.Agg(aggs); err == nil {
+			t.Errorf("Agg(%v): expected an error", aggs)
+		}
+	}
+}
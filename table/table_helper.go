@@ -1,14 +1,13 @@
 package table
 
+// copy returns a Table with its own column names and column map, sharing the column data of t.
 func (t *Table) copy() *Table {
 	columnsCount := len(t.columns)
-	data := make(map[string][]any, columnsCount)
+	data := make(map[string]vector, columnsCount)
 	columns := make([]string, 0, columnsCount)
 
 	for _, c := range t.columns {
-		values := make([]any, len(t.data[c]))
-		copy(values, t.data[c])
-		data[c] = values
+		data[c] = t.data[c]
 
 		columns = append(columns, c)
 	}
@@ -0,0 +1,316 @@
+package table
+
+import "fmt"
+
+// JoinType selects which rows Join keeps.
+type JoinType int
+
+const (
+	// InnerJoin keeps the pairs of rows whose keys match.
+	InnerJoin JoinType = iota
+	// LeftJoin keeps every row of the left table, with missing values where no right row matches.
+	LeftJoin
+	// RightJoin keeps every row of the right table, with missing values where no left row matches.
+	RightJoin
+	// OuterJoin keeps every row of both tables, with missing values where no row of the other table matches.
+	OuterJoin
+	// SemiJoin keeps the rows of the left table that have at least one match, without adding right columns.
+	SemiJoin
+	// AntiJoin keeps the rows of the left table that have no match, without adding right columns.
+	AntiJoin
+)
+
+// String returns the name of the join type.
+func (j JoinType) String() string {
+	switch j {
+	case InnerJoin:
+		return "inner"
+	case LeftJoin:
+		return "left"
+	case RightJoin:
+		return "right"
+	case OuterJoin:
+		return "outer"
+	case SemiJoin:
+		return "semi"
+	case AntiJoin:
+		return "anti"
+	default:
+		return fmt.Sprintf("JoinType(%d)", int(j))
+	}
+}
+
+// JoinOption configures Join.
+type JoinOption func(*joinOptions)
+
+type joinOptions struct {
+	rightOn     []string
+	leftSuffix  string
+	rightSuffix string
+	nullsMatch  bool
+}
+
+func defaultJoinOptions() joinOptions {
+	return joinOptions{
+		leftSuffix:  "_left",
+		rightSuffix: "_right",
+	}
+}
+
+// WithRightOn sets the key columns of the right table, when they are named differently from the key columns of the left table.
+//
+// The columns are paired with the left keys by position.
+func WithRightOn(cols ...string) JoinOption {
+	return func(o *joinOptions) {
+		o.rightOn = cols
+	}
+}
+
+// WithSuffixes sets the suffixes appended to the names of non-key columns present in both tables. The defaults are "_left" and "_right".
+func WithSuffixes(left, right string) JoinOption {
+	return func(o *joinOptions) {
+		o.leftSuffix = left
+		o.rightSuffix = right
+	}
+}
+
+// WithNullKeysMatch makes rows with missing key values match each other.
+//
+// By default a missing key never matches anything, as in SQL.
+func WithNullKeysMatch() JoinOption {
+	return func(o *joinOptions) {
+		o.nullsMatch = true
+	}
+}
+
+// Join combines the rows of the Table with the rows of other whose key columns hold equal values, and returns the result as a new Table.
+//
+// on names the key columns of the Table, and of other unless WithRightOn is used. Numeric keys are compared by value, so int64(1) matches float64(1).
+// The result holds the key columns, then the other columns of the Table, then the other columns of other. Right key columns named like their left key are merged into it; for right and outer joins the merged key takes the right value when there is no left row.
+// Non-key columns present in both tables are renamed with the suffixes set by WithSuffixes. SemiJoin and AntiJoin only return columns of the Table.
+//
+// Left rows keep their order, each followed by its matches in the order of other. For RightJoin the order of other is kept instead, and for OuterJoin unmatched rows of other are appended at the end.
+// An error is returned if other is nil, if no keys are specified, if the number of left and right keys differ, if a key column does not exist, or if an output column name is duplicated. Neither table is modified.
+func (t *Table) Join(other *Table, on []string, how JoinType, argOpts ...JoinOption) (*Table, error) {
+	if other == nil {
+		return nil, fmt.Errorf("join: other table is nil")
+	}
+
+	opts := defaultJoinOptions()
+	for _, opt := range argOpts {
+		opt(&opts)
+	}
+
+	if len(on) == 0 {
+		return nil, fmt.Errorf("join: no key columns specified")
+	}
+
+	rightOn := on
+	if opts.rightOn != nil {
+		rightOn = opts.rightOn
+	}
+	if len(rightOn) != len(on) {
+		return nil, fmt.Errorf("join: %d left keys and %d right keys", len(on), len(rightOn))
+	}
+
+	leftKeys, err := keyVectors(t, on, "left")
+	if err != nil {
+		return nil, err
+	}
+
+	rightKeys, err := keyVectors(other, rightOn, "right")
+	if err != nil {
+		return nil, err
+	}
+
+	left, right := joinRows(leftKeys, t.length, rightKeys, other.length, how, opts.nullsMatch)
+
+	if how == SemiJoin || how == AntiJoin {
+		return t.fetchRows(left), nil
+	}
+
+	return t.joinColumns(other, on, rightOn, left, right, opts)
+}
+
+func keyVectors(t *Table, cols []string, side string) ([]vector, error) {
+	vectors := make([]vector, len(cols))
+	for k, c := range cols {
+		v, ok := t.data[c]
+		if !ok {
+			return nil, fmt.Errorf("join: %s key column %s does not exist", side, c)
+		}
+		vectors[k] = v
+	}
+	return vectors, nil
+}
+
+// joinRows matches the rows of both sides with a hash join on the right keys, and returns the paired row indexes. An index of -1 means the row has no counterpart.
+//
+// For semi and anti joins only the left indexes are returned.
+func joinRows(leftKeys []vector, leftLen int, rightKeys []vector, rightLen int, how JoinType, nullsMatch bool) ([]int, []int) {
+	if how == RightJoin {
+		right, left := joinRows(rightKeys, rightLen, leftKeys, leftLen, LeftJoin, nullsMatch)
+		return left, right
+	}
+
+	hash := make(map[string][]int)
+	for i := 0; i < rightLen; i++ {
+		if !nullsMatch && hasNullKey(rightKeys, i) {
+			continue
+		}
+		key := rowKey(rightKeys, i)
+		hash[key] = append(hash[key], i)
+	}
+
+	var (
+		left, right  []int
+		rightMatched []bool
+	)
+	if how == OuterJoin {
+		rightMatched = make([]bool, rightLen)
+	}
+
+	for i := 0; i < leftLen; i++ {
+		var matches []int
+		if nullsMatch || !hasNullKey(leftKeys, i) {
+			matches = hash[rowKey(leftKeys, i)]
+		}
+
+		switch how {
+		case SemiJoin:
+			if len(matches) > 0 {
+				left = append(left, i)
+			}
+			continue
+		case AntiJoin:
+			if len(matches) == 0 {
+				left = append(left, i)
+			}
+			continue
+		}
+
+		if len(matches) == 0 {
+			if how != InnerJoin {
+				left = append(left, i)
+				right = append(right, -1)
+			}
+			continue
+		}
+
+		for _, j := range matches {
+			left = append(left, i)
+			right = append(right, j)
+			if rightMatched != nil {
+				rightMatched[j] = true
+			}
+		}
+	}
+
+	for j, matched := range rightMatched {
+		if !matched {
+			left = append(left, -1)
+			right = append(right, j)
+		}
+	}
+
+	return left, right
+}
+
+func hasNullKey(keys []vector, i int) bool {
+	for _, v := range keys {
+		if v.IsNull(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinColumns builds the joined Table from the paired row indexes.
+func (t *Table) joinColumns(other *Table, on, rightOn []string, left, right []int, opts joinOptions) (*Table, error) {
+	merged := make(map[string]struct{}, len(on))
+	for k, c := range rightOn {
+		if on[k] == c {
+			merged[c] = struct{}{}
+		}
+	}
+
+	data := make(map[string]vector, len(t.columns)+len(other.columns))
+	columns := make([]string, 0, len(t.columns)+len(other.columns))
+
+	add := func(name string, v vector) error {
+		if _, exists := data[name]; exists {
+			return fmt.Errorf("join: duplicate output column %s", name)
+		}
+		data[name] = v
+		columns = append(columns, name)
+		return nil
+	}
+
+	for k, c := range on {
+		v := t.data[c].Take(left)
+		if _, ok := merged[rightOn[k]]; ok {
+			v = coalesce(v, other.data[rightOn[k]].Take(right))
+		}
+		if err := add(c, v); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, c := range t.columns {
+		if containsColumn(on, c) {
+			continue
+		}
+
+		name := c
+		if _, ok := other.data[c]; ok {
+			name = c + opts.leftSuffix
+		}
+		if err := add(name, t.data[c].Take(left)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, c := range other.columns {
+		if _, ok := merged[c]; ok {
+			continue
+		}
+
+		name := c
+		if _, ok := t.data[c]; ok {
+			name = c + opts.rightSuffix
+		}
+		if err := add(name, other.data[c].Take(right)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Table{
+		columns: columns,
+		data:    data,
+		length:  len(left),
+	}, nil
+}
+
+// coalesce returns a vector holding the values of a, with the missing ones taken from b.
+//
+// a is returned unchanged if it has no missing value that b can fill.
+func coalesce(a, b vector) vector {
+	fill := false
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) && !b.IsNull(i) {
+			fill = true
+			break
+		}
+	}
+	if !fill {
+		return a
+	}
+
+	values := a.Values()
+	for i, v := range values {
+		if v == nil {
+			values[i] = b.At(i)
+		}
+	}
+	return newVector(values)
+}
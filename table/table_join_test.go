@@ -0,0 +1,169 @@
+package table
+
+import (
+	"testing"
+)
+
+func joinTables(tb testing.TB) (*Table, *Table) {
+	orders := mustNew(tb, map[string][]any{
+		"customer": {int64(1), int64(2), int64(1), int64(4), nil},
+		"amount":   {10.0, 20.0, 30.0, 40.0, 50.0},
+	}, "customer", "amount")
+
+	customers := mustNew(tb, map[string][]any{
+		"customer": {1.0, int64(2), int64(3), nil},
+		"name":     {"Alice", "Bob", "Carol", "Nobody"},
+	}, "customer", "name")
+
+	return orders, customers
+}
+
+func TestJoinTypes(t *testing.T) {
+	orders, customers := joinTables(t)
+
+	tests := []struct {
+		how     JoinType
+		columns []string
+		amount  []any
+		name    []any
+	}{
+		{
+			how:     InnerJoin,
+			columns: []string{"customer", "amount", "name"},
+			amount:  []any{10.0, 20.0, 30.0},
+			name:    []any{"Alice", "Bob", "Alice"},
+		},
+		{
+			how:     LeftJoin,
+			columns: []string{"customer", "amount", "name"},
+			amount:  []any{10.0, 20.0, 30.0, 40.0, 50.0},
+			name:    []any{"Alice", "Bob", "Alice", nil, nil},
+		},
+		{
+			how:     RightJoin,
+			columns: []string{"customer", "amount", "name"},
+			amount:  []any{10.0, 30.0, 20.0, nil, nil},
+			name:    []any{"Alice", "Alice", "Bob", "Carol", "Nobody"},
+		},
+		{
+			how:     OuterJoin,
+			columns: []string{"customer", "amount", "name"},
+			amount:  []any{10.0, 20.0, 30.0, 40.0, 50.0, nil, nil},
+			name:    []any{"Alice", "Bob", "Alice", nil, nil, "Carol", "Nobody"},
+		},
+		{
+			how:     SemiJoin,
+			columns: []string{"customer", "amount"},
+			amount:  []any{10.0, 20.0, 30.0},
+		},
+		{
+			how:     AntiJoin,
+			columns: []string{"customer", "amount"},
+			amount:  []any{40.0, 50.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.how.String(), func(t *testing.T) {
+			result, err := orders.Join(customers, []string{"customer"}, tt.how)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertColumns(t, result, tt.columns...)
+			assertValues(t, result, "amount", tt.amount...)
+			if tt.name != nil {
+				assertValues(t, result, "name", tt.name...)
+			}
+		})
+	}
+}
+
+func TestJoinRightKeyFillsMergedKey(t *testing.T) {
+	orders, customers := joinTables(t)
+
+	result, err := orders.Join(customers, []string{"customer"}, OuterJoin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, result, "customer", int64(1), int64(2), int64(1), int64(4), nil, int64(3), nil)
+}
+
+func TestJoinNullKeysMatch(t *testing.T) {
+	orders, customers := joinTables(t)
+
+	result, err := orders.Join(customers, []string{"customer"}, InnerJoin, WithNullKeysMatch())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, result, "name", "Alice", "Bob", "Alice", "Nobody")
+}
+
+func TestJoinOptions(t *testing.T) {
+	left := mustNew(t, map[string][]any{
+		"id":    {int64(1), int64(2)},
+		"value": {"a", "b"},
+	}, "id", "value")
+	right := mustNew(t, map[string][]any{
+		"key":   {int64(2), int64(1)},
+		"value": {"y", "x"},
+	}, "key", "value")
+
+	result, err := left.Join(right, []string{"id"}, InnerJoin, WithRightOn("key"), WithSuffixes("_l", "_r"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertColumns(t, result, "id", "value_l", "key", "value_r")
+	assertValues(t, result, "value_r", "x", "y")
+}
+
+func TestJoinMultipleKeys(t *testing.T) {
+	left := mustNew(t, map[string][]any{
+		"a": {int64(1), int64(1), int64(2)},
+		"b": {"x", "y", "x"},
+		"v": {int64(10), int64(20), int64(30)},
+	}, "a", "b", "v")
+	right := mustNew(t, map[string][]any{
+		"a": {int64(1), int64(2)},
+		"b": {"y", "x"},
+		"w": {"first", "second"},
+	}, "a", "b", "w")
+
+	result, err := left.Join(right, []string{"a", "b"}, LeftJoin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, result, "w", nil, "first", "second")
+}
+
+func TestJoinErrors(t *testing.T) {
+	orders, customers := joinTables(t)
+
+	tests := []struct {
+		name  string
+		other *Table
+		on    []string
+		opts  []JoinOption
+	}{
+		{name: "nil table", on: []string{"customer"}},
+		{name: "no keys", other: customers},
+		{name: "missing left key", other: customers, on: []string{"missing"}},
+		{name: "missing right key", other: customers, on: []string{"amount"}},
+		{name: "key count", other: customers, on: []string{"customer"}, opts: []JoinOption{WithRightOn("customer", "name")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := orders.Join(tt.other, tt.on, InnerJoin, tt.opts...); err == nil {
+				t.Error("Join: expected an error")
+			}
+		})
+	}
+
+	left := mustNew(t, map[string][]any{"id": {int64(1)}, "value": {"a"}, "value_right": {"b"}}, "id", "value", "value_right")
+	right := mustNew(t, map[string][]any{"id": {int64(1)}, "value": {"c"}}, "id", "value")
+	if _, err := left.Join(right, []string{"id"}, InnerJoin); err == nil {
+		t.Error("Join: expected an error for a duplicated output column")
+	}
+}
@@ -0,0 +1,193 @@
+package table
+
+import "fmt"
+
+// DropNA returns a new Table without the rows that have a missing value in any of the given columns.
+//
+// If no columns are provided, every column of the table is checked. An error is returned if any column does not exist. The original Table is not modified.
+func (t *Table) DropNA(cols ...string) (*Table, error) {
+	return t.dropNA("drop na", cols, func(present, total int) bool {
+		return present == total
+	})
+}
+
+// DropNAAll returns a new Table without the rows whose values are missing in all of the given columns.
+//
+// If no columns are provided, every column of the table is checked. An error is returned if any column does not exist. The original Table is not modified.
+func (t *Table) DropNAAll(cols ...string) (*Table, error) {
+	return t.dropNA("drop na", cols, func(present, total int) bool {
+		return present > 0
+	})
+}
+
+// DropNAThresh returns a new Table keeping only the rows that have at least thresh non-missing values in the given columns.
+//
+// If no columns are provided, every column of the table is checked. An error is returned if thresh is negative or if any column does not exist. The original Table is not modified.
+func (t *Table) DropNAThresh(thresh int, cols ...string) (*Table, error) {
+	if thresh < 0 {
+		return nil, fmt.Errorf("drop na: threshold must not be negative, got %d", thresh)
+	}
+
+	return t.dropNA("drop na", cols, func(present, total int) bool {
+		return present >= thresh
+	})
+}
+
+func (t *Table) dropNA(op string, cols []string, keep func(present, total int) bool) (*Table, error) {
+	vectors, err := t.vectors(op, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := []int{}
+	for i := 0; i < t.length; i++ {
+		present := 0
+		for _, v := range vectors {
+			if !v.IsNull(i) {
+				present++
+			}
+		}
+
+		if keep(present, len(vectors)) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return t.fetchRows(indexes), nil
+}
+
+// FillNA returns a new Table where missing values of the given columns are replaced with value.
+//
+// If no columns are provided, every column is filled. See Column.FillNA for how numeric fill values are converted. An error is returned if any column does not exist. The original Table is not modified.
+func (t *Table) FillNA(value any, cols ...string) (*Table, error) {
+	return t.mapColumns("fill na", cols, func(c *Column) (*Column, error) {
+		return c.FillNA(value), nil
+	})
+}
+
+// FillNAMap returns a new Table where missing values of each column in values are replaced with the value mapped to it.
+//
+// Columns not present in values are copied as-is. An error is returned if any column does not exist. The original Table is not modified.
+func (t *Table) FillNAMap(values map[string]any) (*Table, error) {
+	cols := make([]string, 0, len(values))
+	for c := range values {
+		cols = append(cols, c)
+	}
+
+	return t.mapColumns("fill na", cols, func(c *Column) (*Column, error) {
+		return c.FillNA(values[c.name]), nil
+	})
+}
+
+// FillNAStrategy returns a new Table where missing values of the given columns are replaced with the mean, median or mode of each column.
+//
+// If no columns are provided, FillMean and FillMedian fill every numeric column and FillMode fills every column.
+// An error is returned if any column does not exist, or if a column given explicitly is not numeric for FillMean and FillMedian. The original Table is not modified.
+func (t *Table) FillNAStrategy(strategy FillStrategy, cols ...string) (*Table, error) {
+	if len(cols) == 0 && strategy != FillMode {
+		if cols = t.numericColumns(); len(cols) == 0 {
+			return t.copy(), nil
+		}
+	}
+
+	return t.mapColumns("fill na", cols, func(c *Column) (*Column, error) {
+		return c.FillNAStrategy(strategy)
+	})
+}
+
+// FFill returns a new Table where missing values of the given columns are replaced with the last non-missing value above them.
+//
+// If no columns are provided, every column is filled. Missing values at the top of a column stay missing. An error is returned if any column does not exist. The original Table is not modified.
+func (t *Table) FFill(cols ...string) (*Table, error) {
+	return t.mapColumns("ffill", cols, func(c *Column) (*Column, error) {
+		return c.FFill(), nil
+	})
+}
+
+// BFill returns a new Table where missing values of the given columns are replaced with the next non-missing value below them.
+//
+// If no columns are provided, every column is filled. Missing values at the bottom of a column stay missing. An error is returned if any column does not exist. The original Table is not modified.
+func (t *Table) BFill(cols ...string) (*Table, error) {
+	return t.mapColumns("bfill", cols, func(c *Column) (*Column, error) {
+		return c.BFill(), nil
+	})
+}
+
+// Interpolate returns a new Table where missing values of the given numeric columns are filled by linear interpolation, see Column.Interpolate.
+//
+// If no columns are provided, every numeric column is interpolated. An error is returned if any column does not exist or is not numeric. The original Table is not modified.
+func (t *Table) Interpolate(cols ...string) (*Table, error) {
+	if len(cols) == 0 {
+		if cols = t.numericColumns(); len(cols) == 0 {
+			return t.copy(), nil
+		}
+	}
+
+	return t.mapColumns("interpolate", cols, func(c *Column) (*Column, error) {
+		return c.Interpolate()
+	})
+}
+
+// vectors returns the storage of the given columns, or of every column if none are given.
+func (t *Table) vectors(op string, cols []string) ([]vector, error) {
+	if len(cols) == 0 {
+		cols = t.columns
+	}
+
+	vectors := make([]vector, 0, len(cols))
+	for _, c := range cols {
+		v, ok := t.data[c]
+		if !ok {
+			return nil, fmt.Errorf("%s: column %s does not exist", op, c)
+		}
+		vectors = append(vectors, v)
+	}
+
+	return vectors, nil
+}
+
+// mapColumns returns a new Table where each of the given columns, or every column if none are given, is replaced with the result of f. Other columns are copied.
+func (t *Table) mapColumns(op string, cols []string, f func(*Column) (*Column, error)) (*Table, error) {
+	if _, err := t.vectors(op, cols); err != nil {
+		return nil, err
+	}
+
+	if len(cols) == 0 {
+		cols = t.columns
+	}
+
+	data := make(map[string]vector, len(t.columns))
+	columns := make([]string, 0, len(t.columns))
+
+	for _, c := range t.columns {
+		columns = append(columns, c)
+
+		if !containsColumn(cols, c) {
+			data[c] = t.data[c]
+			continue
+		}
+
+		col, err := f(&Column{name: c, data: t.data[c]})
+		if err != nil {
+			return nil, err
+		}
+		data[c] = col.data
+	}
+
+	return &Table{
+		columns: columns,
+		data:    data,
+		length:  t.length,
+	}, nil
+}
+
+// numericColumns returns the names of the columns holding numeric values, in table order.
+func (t *Table) numericColumns() []string {
+	cols := []string{}
+	for _, c := range t.columns {
+		if isNumericColumn(&Column{name: c, data: t.data[c]}) {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
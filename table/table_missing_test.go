@@ -0,0 +1,146 @@
+package table
+
+import (
+	"testing"
+)
+
+func missingTable(tb testing.TB) *Table {
+	return mustNew(tb, map[string][]any{
+		"a": {int64(1), nil, int64(3), nil},
+		"b": {"x", nil, nil, "y"},
+		"c": {1.0, nil, 3.0, 4.0},
+	}, "a", "b", "c")
+}
+
+func TestDropNA(t *testing.T) {
+	tests := []struct {
+		name string
+		drop func(*Table) (*Table, error)
+		want []any
+	}{
+		{name: "any", drop: func(t *Table) (*Table, error) { return t.DropNA() }, want: []any{int64(1)}},
+		{name: "any of a", drop: func(t *Table) (*Table, error) { return t.DropNA("a") }, want: []any{int64(1), int64(3)}},
+		{name: "all", drop: func(t *Table) (*Table, error) { return t.DropNAAll() }, want: []any{int64(1), int64(3), nil}},
+		{name: "all of a and b", drop: func(t *Table) (*Table, error) { return t.DropNAAll("a", "b") }, want: []any{int64(1), int64(3), nil}},
+		{name: "thresh 2", drop: func(t *Table) (*Table, error) { return t.DropNAThresh(2) }, want: []any{int64(1), int64(3), nil}},
+		{name: "thresh 3", drop: func(t *Table) (*Table, error) { return t.DropNAThresh(3) }, want: []any{int64(1)}},
+		{name: "thresh 0", drop: func(t *Table) (*Table, error) { return t.DropNAThresh(0) }, want: []any{int64(1), nil, int64(3), nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.drop(missingTable(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertValues(t, result, "a", tt.want...)
+		})
+	}
+}
+
+func TestDropNAErrors(t *testing.T) {
+	tbl := missingTable(t)
+
+	if _, err := tbl.DropNA("missing"); err == nil {
+		t.Error("DropNA: expected an error for a column that does not exist")
+	}
+	if _, err := tbl.DropNAThresh(-1); err == nil {
+		t.Error("DropNAThresh: expected an error for a negative threshold")
+	}
+}
+
+func TestFillNA(t *testing.T) {
+	tbl := missingTable(t)
+
+	filled, err := tbl.FillNA(int64(0), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, filled, "a", int64(1), int64(0), int64(3), int64(0))
+	assertValues(t, filled, "b", "x", nil, nil, "y")
+	if got := filled.MustCol("a").Type(); got != TypeInt {
+		t.Errorf("type after filling with an integer = %v, want %v", got, TypeInt)
+	}
+
+	filled, err = tbl.FillNA(0.5, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, filled, "a", 1.0, 0.5, 3.0, 0.5)
+
+	filled, err = tbl.FillNAMap(map[string]any{"b": "?", "c": 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, filled, "b", "x", "?", "?", "y")
+	assertValues(t, filled, "c", 1.0, 0.0, 3.0, 4.0)
+
+	if _, err := tbl.FillNAMap(map[string]any{"missing": 0}); err == nil {
+		t.Error("FillNAMap: expected an error for a column that does not exist")
+	}
+
+	// the original table is not modified
+	assertValues(t, tbl, "a", int64(1), nil, int64(3), nil)
+}
+
+func TestFillNAStrategy(t *testing.T) {
+	tbl := missingTable(t)
+
+	tests := []struct {
+		strategy FillStrategy
+		col      string
+		want     []any
+	}{
+		{strategy: FillMean, col: "c", want: []any{1.0, 8.0 / 3, 3.0, 4.0}},
+		{strategy: FillMedian, col: "c", want: []any{1.0, 3.0, 3.0, 4.0}},
+		{strategy: FillMode, col: "b", want: []any{"x", "x", "x", "y"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy.String(), func(t *testing.T) {
+			filled, err := tbl.FillNAStrategy(tt.strategy, tt.col)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertValues(t, filled, tt.col, tt.want...)
+		})
+	}
+
+	if _, err := tbl.FillNAStrategy(FillMean, "b"); err == nil {
+		t.Error("FillNAStrategy: expected an error for the mean of a string column")
+	}
+}
+
+func TestFFillBFill(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{"x": {nil, int64(1), nil, nil, int64(4), nil}}, "x")
+
+	ffilled, err := tbl.FFill()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, ffilled, "x", nil, int64(1), int64(1), int64(1), int64(4), int64(4))
+
+	bfilled, err := tbl.BFill("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, bfilled, "x", int64(1), int64(1), int64(4), int64(4), int64(4), nil)
+}
+
+func TestInterpolate(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"x": {nil, int64(1), nil, nil, int64(4), nil},
+		"s": {"a", nil, "b", "c", "d", "e"},
+	}, "x", "s")
+
+	result, err := tbl.Interpolate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, result, "x", nil, 1.0, 2.0, 3.0, 4.0, nil)
+	assertValues(t, result, "s", "a", nil, "b", "c", "d", "e")
+
+	if _, err := tbl.Interpolate("s"); err == nil {
+		t.Error("Interpolate: expected an error for a string column")
+	}
+}
@@ -4,9 +4,13 @@ import "fmt"
 
 // RenameColumn renames oldName to newName.
 //
-// It returns an error if oldName does not exist or newName is already in use.
+// It returns an error if oldName does not exist, newName is already in use, or the table is frozen.
 // It is a no-op if oldName and newName are the same.
 func (t *Table) RenameColumn(oldName, newName string) error {
+	if t.frozen {
+		return fmt.Errorf("rename: %w", ErrFrozen)
+	}
+
 	if newName == oldName {
 		return nil
 	}
@@ -25,7 +29,7 @@ func (t *Table) RenameColumn(oldName, newName string) error {
 	return nil
 }
 
-func renameColumn(t *Table, oldName, newName string, values []any) {
+func renameColumn(t *Table, oldName, newName string, values vector) {
 	delete(t.data, oldName)
 	t.data[newName] = values
 
@@ -39,10 +43,14 @@ func renameColumn(t *Table, oldName, newName string, values []any) {
 
 // RenameColumns renames multiple columns based on the provided map (oldName -> newName).
 //
-// If any rename is invalid, no changes are applied to the table.
+// If any rename is invalid, or the table is frozen, no changes are applied to the table.
 func (t *Table) RenameColumns(nameMap map[string]string) error {
+	if t.frozen {
+		return fmt.Errorf("rename: %w", ErrFrozen)
+	}
+
 	usedColumns := make(map[string]struct{})
-	originalValues := make(map[string][]any)
+	originalValues := make(map[string]vector)
 
 	for _, c := range t.columns {
 		if _, isAssigned := nameMap[c]; !isAssigned {
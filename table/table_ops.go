@@ -1,6 +1,11 @@
 package table
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-rowan/rowan/internal/parallel"
+)
 
 // MapCol applies the provided function `f` to all values in the specified column
 // of the Table, returning a new Table with the updated column. All other columns
@@ -22,14 +27,12 @@ func (t *Table) MapCol(name string, f func(any) any) (*Table, error) {
 	newCol := oldCol.Map(f)
 	columns := make([]string, 0, len(t.columns))
 
-	data := make(map[string][]any, len(t.columns))
+	data := make(map[string]vector, len(t.columns))
 	for _, c := range t.columns {
 		if c == name {
-			data[c] = append([]any(nil), newCol.data...)
+			data[c] = newCol.data
 		} else {
-			values := make([]any, len(t.data[c]))
-			copy(values, t.data[c])
-			data[c] = values
+			data[c] = t.data[c]
 		}
 
 		columns = append(columns, c)
@@ -44,31 +47,45 @@ func (t *Table) MapCol(name string, f func(any) any) (*Table, error) {
 
 // Categorize returns a new Table where each categorical column produces an additional column with encoded integer values.
 //
-// For every categorical column, a new column named "<column>_categorized" is appended. Each unique value in the original column is mapped to a zero-based integer, preserving row order. Non-categorical columns are copied as-is.
+// For every categorical column, a new column named "<column>_categorized" is appended. Each unique value in the original column is mapped to a zero-based integer, preserving row order. Missing values stay missing. Non-categorical columns are copied as-is.
+// Columns are processed in parallel, see SetWorkers.
 //
 // The original Table is not modified.
 func (t *Table) Categorize() *Table {
+	result, _ := t.CategorizeContext(context.Background())
+	return result
+}
+
+// CategorizeContext is like Categorize, but stops encoding columns and returns ctx.Err() when ctx is done before all columns have been processed.
+func (t *Table) CategorizeContext(ctx context.Context) (*Table, error) {
 	columnsCount := len(t.columns)
-	data := make(map[string][]any, columnsCount*2)
-	columns := make([]string, 0, columnsCount*2)
+	encoded := make([]vector, columnsCount)
 
-	for _, c := range t.columns {
-		originData := t.data[c]
+	err := parallel.For(ctx, columnsCount, func(i int) error {
+		col, _ := t.Col(t.columns[i])
+		if col.categorical {
+			ctgData, _ := categorize(col.name, col.Values())
+			encoded[i] = newVector(ctgData)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		d := make([]any, len(originData))
-		copy(d, originData)
-		data[c] = d
+	data := make(map[string]vector, columnsCount*2)
+	columns := make([]string, 0, columnsCount*2)
 
+	for i, c := range t.columns {
+		data[c] = t.data[c]
 		columns = append(columns, c)
 
-		col, _ := t.Col(c)
-		if !col.categorical {
+		if encoded[i] == nil {
 			continue
 		}
 
-		ctgData, headerName := categorize(c, d)
-
-		data[headerName] = ctgData
+		headerName := c + "_categorized"
+		data[headerName] = encoded[i]
 		columns = append(columns, headerName)
 	}
 
@@ -76,7 +93,7 @@ func (t *Table) Categorize() *Table {
 		columns: columns,
 		data:    data,
 		length:  t.length,
-	}
+	}, nil
 }
 
 func categorize(name string, data []any) ([]any, string) {
@@ -86,6 +103,10 @@ func categorize(name string, data []any) ([]any, string) {
 	index := 0
 
 	for i, v := range data {
+		if v == nil {
+			continue
+		}
+
 		if _, ok := ctgMap[v]; !ok {
 			ctgMap[v] = index
 			index++
@@ -104,27 +125,21 @@ func categorize(name string, data []any) ([]any, string) {
 //
 // Returns an error only if Table construction fails.
 func (t *Table) Where(f func(row map[string]any) bool) (*Table, error) {
-	filtered := make(map[string][]any)
 	cols := t.Columns()
-
-	for _, c := range cols {
-		filtered[c] = []any{}
-	}
+	indexes := []int{}
 
 	for i := 0; i < t.Len(); i++ {
 		row := make(map[string]any)
 		for _, c := range cols {
-			row[c] = t.data[c][i]
+			row[c] = t.data[c].At(i)
 		}
 
 		if f(row) {
-			for _, c := range cols {
-				filtered[c] = append(filtered[c], t.data[c][i])
-			}
+			indexes = append(indexes, i)
 		}
 	}
 
-	return New(filtered, cols)
+	return t.fetchRows(indexes), nil
 }
 
 // AddColumns returns a new Table with one or more columns appended.
@@ -161,22 +176,16 @@ func (t *Table) AddColumns(args map[string][]any) (*Table, error) {
 		}
 	}
 
-	data := make(map[string][]any, len(t.data)+argsCount)
+	data := make(map[string]vector, len(t.data)+argsCount)
 	columns := make([]string, 0, len(t.data)+argsCount)
 
 	for _, c := range t.columns {
-		v := make([]any, len(t.data[c]))
-		copy(v, t.data[c])
-
-		data[c] = v
+		data[c] = t.data[c]
 		columns = append(columns, c)
 	}
 
 	for name, values := range args {
-		v := make([]any, len(values))
-		copy(v, values)
-
-		data[name] = v
+		data[name] = newVector(values)
 		columns = append(columns, name)
 	}
 
@@ -202,8 +211,12 @@ func (t *Table) AddColumn(name string, values []any) (*Table, error) {
 // The column must already exist in the table.
 // The length of values must match the table length.
 //
-// This method does not modify the column order.
+// This method does not modify the column order. It returns an error if the table is frozen.
 func (t *Table) ReplaceColumn(name string, values []any) error {
+	if t.frozen {
+		return fmt.Errorf("replace column: %w", ErrFrozen)
+	}
+
 	if t.data == nil {
 		return fmt.Errorf("replace column: table has no data")
 	}
@@ -220,9 +233,36 @@ func (t *Table) ReplaceColumn(name string, values []any) error {
 		)
 	}
 
-	data := make([]any, valuesCount)
-	copy(data, values)
+	t.data[name] = newVector(values)
+	return nil
+}
+
+// SetCol replaces the data of an existing column with the values of col.
+//
+// The column to replace is the one named col.Name(), and it must already exist in the table. The length of col must match the table length.
+// Like ReplaceColumn, SetCol mutates the table and does not modify the column order. The typed storage of col is used as-is, without converting the values to []any.
+// It returns an error if the table is frozen.
+func (t *Table) SetCol(col *Column) error {
+	if t.frozen {
+		return fmt.Errorf("set column: %w", ErrFrozen)
+	}
+
+	if col == nil {
+		return fmt.Errorf("set column: column is nil")
+	}
+
+	if _, ok := t.data[col.name]; !ok {
+		return fmt.Errorf("set column: column %s does not exist", col.name)
+	}
+
+	valuesCount := col.data.Len()
+	if valuesCount != t.length {
+		return fmt.Errorf(
+			"set column: length mismatch for column %s got %d, expected %d",
+			col.name, valuesCount, t.length,
+		)
+	}
 
-	t.data[name] = data
+	t.data[col.name] = col.data
 	return nil
 }
@@ -1,6 +1,9 @@
 package table
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Overview prints a summary of the table to the standard output.
 //
@@ -25,31 +28,41 @@ func (t *Table) Overview() {
 		col, _ := t.Col(c)
 
 		meta["Name"] = append(meta["Name"], c)
-		meta["Type"] = append(meta["Type"], columnType(col.Values()))
+		meta["Type"] = append(meta["Type"], columnType(col.data))
 	}
 
 	metaTbl, _ := New(meta, []string{"Name", "Type"})
 	metaTbl.Display()
 }
 
-func columnType(data []any) string {
-	for _, v := range data {
-		if v == nil {
+func columnType(data vector) string {
+	if t := data.Type(); t != TypeUnknown {
+		return t.String()
+	}
+
+	for i := 0; i < data.Len(); i++ {
+		if data.IsNull(i) {
 			continue
 		}
 
-		switch v.(type) {
-		case int, int64:
-			return "int"
-		case float32, float64:
-			return "float"
-		case bool:
-			return "bool"
-		case string:
-			return "string"
-		default:
-			return "unknown"
-		}
+		return valueType(data.At(i)).String()
+	}
+	return TypeUnknown.String()
+}
+
+func valueType(v any) Type {
+	switch v.(type) {
+	case int, int64:
+		return TypeInt
+	case float32, float64:
+		return TypeFloat
+	case bool:
+		return TypeBool
+	case string:
+		return TypeString
+	case time.Time:
+		return TypeTime
+	default:
+		return TypeUnknown
 	}
-	return "unknown"
 }
@@ -0,0 +1,158 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Melt returns a new Table in long format, with one row per original row and value column.
+//
+// The id columns are repeated for every value column. The varName column holds the name of the value column each row comes from, and the valueName column holds its value.
+// Rows are ordered by value column, then by original row. If valueCols is empty, every column that is not an id column is melted.
+// Values are combined the same way as Concat, so melting int64 and float64 columns yields a float64 value column.
+// An error is returned if a column does not exist, if no value columns remain, or if varName or valueName is empty or collides with an id column. The original Table is not modified.
+func (t *Table) Melt(idCols, valueCols []string, varName, valueName string) (*Table, error) {
+	if varName == "" || valueName == "" || varName == valueName {
+		return nil, fmt.Errorf("melt: variable and value column names must be distinct and non-empty")
+	}
+
+	for _, c := range append(slices.Clone(idCols), valueCols...) {
+		if _, ok := t.data[c]; !ok {
+			return nil, fmt.Errorf("melt: column %s does not exist", c)
+		}
+	}
+
+	for _, name := range []string{varName, valueName} {
+		if containsColumn(idCols, name) {
+			return nil, fmt.Errorf("melt: column %s already exists", name)
+		}
+	}
+
+	if len(valueCols) == 0 {
+		for _, c := range t.columns {
+			if !containsColumn(idCols, c) {
+				valueCols = append(valueCols, c)
+			}
+		}
+	}
+	if len(valueCols) == 0 {
+		return nil, fmt.Errorf("melt: no value columns")
+	}
+
+	length := t.length * len(valueCols)
+	indexes := make([]int, 0, length)
+	names := make([]any, 0, length)
+	parts := make([]vector, len(valueCols))
+	lengths := make([]int, len(valueCols))
+
+	for k, c := range valueCols {
+		for i := 0; i < t.length; i++ {
+			indexes = append(indexes, i)
+			names = append(names, c)
+		}
+		parts[k] = t.data[c]
+		lengths[k] = t.length
+	}
+
+	data := make(map[string]vector, len(idCols)+2)
+	columns := make([]string, 0, len(idCols)+2)
+
+	for _, c := range idCols {
+		data[c] = t.data[c].Take(indexes)
+		columns = append(columns, c)
+	}
+
+	data[varName] = newVector(names)
+	data[valueName] = concatVectors(parts, lengths)
+	columns = append(columns, varName, valueName)
+
+	return &Table{
+		columns: columns,
+		data:    data,
+		length:  length,
+	}, nil
+}
+
+// Pivot returns a new Table in wide format, with one row per distinct value of the index column and one column per distinct value of the columns column.
+//
+// Each cell holds aggFunc applied to the values column over the rows sharing that index and columns value, or a missing value if there are none. Any AggFunc can be used, such as AggSum, AggMean or AggCount.
+// Rows follow the order of first appearance of the index values. Pivoted columns are sorted by value, with a missing value last, and named after their value formatted with fmt, times as dates.
+// An error is returned if a column does not exist, if aggFunc has no function, or if a pivoted column name collides with the index column. The original Table is not modified.
+func (t *Table) Pivot(index, columns, values string, aggFunc AggFunc) (*Table, error) {
+	for _, c := range []string{index, columns, values} {
+		if _, ok := t.data[c]; !ok {
+			return nil, fmt.Errorf("pivot: column %s does not exist", c)
+		}
+	}
+
+	if aggFunc.Fn == nil {
+		return nil, fmt.Errorf("pivot: aggregation has no function")
+	}
+
+	rowGroups := groupIndexes([]vector{t.data[index]}, t.length)
+	colGroups := groupIndexes([]vector{t.data[columns]}, t.length)
+
+	pivotVector := t.data[columns]
+	slices.SortStableFunc(colGroups, func(a, b []int) int {
+		return compareRows(pivotVector, a[0], b[0], SortKey{})
+	})
+
+	rowOf := make([]int, t.length)
+	for g, group := range rowGroups {
+		for _, i := range group {
+			rowOf[i] = g
+		}
+	}
+
+	firsts := make([]int, len(rowGroups))
+	for g, group := range rowGroups {
+		firsts[g] = group[0]
+	}
+
+	data := map[string]vector{index: t.data[index].Take(firsts)}
+	names := []string{index}
+
+	for _, group := range colGroups {
+		name := pivotName(pivotVector.At(group[0]))
+		if _, exists := data[name]; exists {
+			return nil, fmt.Errorf("pivot: duplicate output column %s", name)
+		}
+
+		cells := make([][]int, len(rowGroups))
+		for _, i := range group {
+			cells[rowOf[i]] = append(cells[rowOf[i]], i)
+		}
+
+		results := make([]any, len(rowGroups))
+		for g, rows := range cells {
+			if len(rows) == 0 {
+				continue
+			}
+			results[g] = aggFunc.Fn(&Column{
+				name: values,
+				data: t.data[values].Take(rows),
+			})
+		}
+
+		data[name] = newVector(results)
+		names = append(names, name)
+	}
+
+	return &Table{
+		columns: names,
+		data:    data,
+		length:  len(rowGroups),
+	}, nil
+}
+
+func pivotName(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case time.Time:
+		return formatTime(x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
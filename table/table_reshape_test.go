@@ -0,0 +1,149 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMelt(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"id": {"a", "b"},
+		"q1": {int64(1), int64(2)},
+		"q2": {2.5, nil},
+	}, "id", "q1", "q2")
+
+	tests := []struct {
+		name      string
+		valueCols []string
+		variables []any
+		values    []any
+	}{
+		{
+			name:      "all columns",
+			variables: []any{"q1", "q1", "q2", "q2"},
+			values:    []any{1.0, 2.0, 2.5, nil},
+		},
+		{
+			name:      "selected columns",
+			valueCols: []string{"q1"},
+			variables: []any{"q1", "q1"},
+			values:    []any{int64(1), int64(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tbl.Melt([]string{"id"}, tt.valueCols, "quarter", "sales")
+			if err != nil {
+				t.Fatalf("Melt: %v", err)
+			}
+
+			ids := make([]any, 0, len(tt.values))
+			for range len(tt.values) / 2 {
+				ids = append(ids, "a", "b")
+			}
+
+			assertColumns(t, result, "id", "quarter", "sales")
+			assertValues(t, result, "id", ids...)
+			assertValues(t, result, "quarter", tt.variables...)
+			assertValues(t, result, "sales", tt.values...)
+		})
+	}
+}
+
+func TestMeltErrors(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{"id": {"a"}, "q1": {int64(1)}}, "id", "q1")
+
+	tests := []struct {
+		name      string
+		idCols    []string
+		valueCols []string
+		varName   string
+		valueName string
+		want      string
+	}{
+		{"empty name", []string{"id"}, nil, "", "value", "must be distinct and non-empty"},
+		{"same names", []string{"id"}, nil, "x", "x", "must be distinct and non-empty"},
+		{"missing id", []string{"nope"}, nil, "variable", "value", "column nope does not exist"},
+		{"missing value", []string{"id"}, []string{"nope"}, "variable", "value", "column nope does not exist"},
+		{"name collides with id", []string{"id"}, nil, "id", "value", "column id already exists"},
+		{"no value columns", []string{"id", "q1"}, nil, "variable", "value", "no value columns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tbl.Melt(tt.idCols, tt.valueCols, tt.varName, tt.valueName)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPivot(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"store":   {"x", "x", "y", "x", "y"},
+		"quarter": {int64(2), int64(1), int64(1), int64(1), nil},
+		"sales":   {10.0, 20.0, 30.0, 40.0, 50.0},
+	}, "store", "quarter", "sales")
+
+	tests := []struct {
+		name string
+		agg  AggFunc
+		want map[string][]any
+	}{
+		{
+			name: "sum",
+			agg:  AggSum,
+			want: map[string][]any{"1": {60.0, 30.0}, "2": {10.0, nil}, "null": {nil, 50.0}},
+		},
+		{
+			name: "count",
+			agg:  AggCount,
+			want: map[string][]any{"1": {int64(2), int64(1)}, "2": {int64(1), nil}, "null": {nil, int64(1)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tbl.Pivot("store", "quarter", "sales", tt.agg)
+			if err != nil {
+				t.Fatalf("Pivot: %v", err)
+			}
+
+			assertColumns(t, result, "store", "1", "2", "null")
+			assertValues(t, result, "store", "x", "y")
+			for c, want := range tt.want {
+				assertValues(t, result, c, want...)
+			}
+		})
+	}
+}
+
+func TestPivotErrors(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"store": {"x", "y"},
+		"kind":  {"store", "x"},
+		"sales": {1.0, 2.0},
+	}, "store", "kind", "sales")
+
+	tests := []struct {
+		name    string
+		columns string
+		agg     AggFunc
+		want    string
+	}{
+		{"missing column", "nope", AggSum, "column nope does not exist"},
+		{"no function", "kind", AggFunc{Name: "bad"}, "aggregation has no function"},
+		{"name collides with index", "kind", AggSum, "duplicate output column store"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tbl.Pivot("store", tt.columns, "sales", tt.agg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
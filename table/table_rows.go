@@ -63,24 +63,13 @@ func (t *Table) SelectRows(indexes []int) (*Table, error) {
 		return EmptyTableFrom(t), nil
 	}
 
-	data := make(map[string][]any, len(t.columns))
-
-	for _, c := range t.columns {
-		originCol := t.data[c]
-		col := make([]any, indexCount)
-
-		for i, index := range indexes {
-			if index < 0 || index >= t.length {
-				return nil, fmt.Errorf("select rows: index out of range at the order of %d", i)
-			}
-
-			col[i] = originCol[index]
+	for i, index := range indexes {
+		if index < 0 || index >= t.length {
+			return nil, fmt.Errorf("select rows: index out of range at the order of %d", i)
 		}
-
-		data[c] = col
 	}
 
-	return New(data, t.columns)
+	return t.fetchRows(indexes), nil
 }
 
 // MustSelectRows returns a new Table containing only the rows specified by the given indices.
@@ -95,28 +84,11 @@ func (t *Table) MustSelectRows(indexes []int) *Table {
 		return EmptyTableFrom(t)
 	}
 
-	data := make(map[string][]any, len(t.columns))
-	columns := []string{}
-
-	for _, c := range t.columns {
-		originCol := t.data[c]
-		col := make([]any, indexCount)
-
-		for i, index := range indexes {
-			if index < 0 || index >= t.length {
-				panic("select rows: index out of range")
-			}
-
-			col[i] = originCol[index]
+	for _, index := range indexes {
+		if index < 0 || index >= t.length {
+			panic("select rows: index out of range")
 		}
-
-		data[c] = col
-		columns = append(columns, c)
 	}
 
-	return &Table{
-		columns: columns,
-		data:    data,
-		length:  indexCount,
-	}
+	return t.fetchRows(indexes)
 }
@@ -11,7 +11,7 @@ func (t *Table) Select(cols ...string) (*Table, error) {
 		return nil, fmt.Errorf("select: no columns specified")
 	}
 
-	data := make(map[string][]any, argsCount)
+	data := make(map[string]vector, argsCount)
 	columns := make([]string, 0, argsCount)
 
 	for _, col := range cols {
@@ -20,10 +20,7 @@ func (t *Table) Select(cols ...string) (*Table, error) {
 			return nil, fmt.Errorf("select: column %s does not exist", col)
 		}
 
-		values := make([]any, len(v))
-		copy(values, v)
-
-		data[col] = values
+		data[col] = v
 		columns = append(columns, col)
 	}
 
@@ -54,7 +51,7 @@ func (t *Table) Drop(cols ...string) (*Table, error) {
 		dropSet[c] = struct{}{}
 	}
 
-	data := make(map[string][]any)
+	data := make(map[string]vector)
 	columns := make([]string, 0, len(t.columns))
 
 	for _, c := range t.columns {
@@ -62,10 +59,7 @@ func (t *Table) Drop(cols ...string) (*Table, error) {
 			continue
 		}
 
-		values := make([]any, len(t.data[c]))
-		copy(values, t.data[c])
-
-		data[c] = values
+		data[c] = t.data[c]
 		columns = append(columns, c)
 	}
 
@@ -0,0 +1,120 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SortKey describes how a single column takes part in the ordering of SortBy.
+//
+// The zero value sorts the column in ascending order with missing values last, using the default comparison of values:
+// numeric values are compared numerically whatever their Go type, strings lexicographically, times chronologically and false before true.
+type SortKey struct {
+	// Column is the name of the column to sort by.
+	Column string
+	// Descending reverses the order of the non-missing values.
+	Descending bool
+	// NullsFirst places missing values before all other values. It is not affected by Descending.
+	NullsFirst bool
+	// Compare optionally replaces the default comparison. It is only called with non-missing values and must return a negative number, zero or a positive number when a sorts before, equal to or after b in ascending order.
+	Compare func(a, b any) int
+}
+
+// Asc returns a SortKey sorting the named column in ascending order with missing values last.
+func Asc(column string) SortKey {
+	return SortKey{Column: column}
+}
+
+// Desc returns a SortKey sorting the named column in descending order with missing values last.
+func Desc(column string) SortKey {
+	return SortKey{Column: column, Descending: true}
+}
+
+// SortBy returns a new Table with its rows ordered by the given keys.
+//
+// Rows are compared by the first key, ties are broken by the following keys, and rows that compare equal on every key keep their original relative order.
+// An error is returned if no keys are given or if any key refers to a column that does not exist. The original Table is not modified.
+func (t *Table) SortBy(keys ...SortKey) (*Table, error) {
+	indexes, err := t.Argsort(keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.fetchRows(indexes), nil
+}
+
+// Argsort returns the row indexes that would sort the Table by the given keys, see SortBy.
+//
+// The returned indexes can be passed to SelectRows.
+func (t *Table) Argsort(keys ...SortKey) ([]int, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("sort: no keys specified")
+	}
+
+	vectors := make([]vector, len(keys))
+	for k, key := range keys {
+		v, ok := t.data[key.Column]
+		if !ok {
+			return nil, fmt.Errorf("sort: column %s does not exist", key.Column)
+		}
+		vectors[k] = v
+	}
+
+	indexes := make([]int, t.length)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	slices.SortStableFunc(indexes, func(i, j int) int {
+		for k, key := range keys {
+			if c := compareRows(vectors[k], i, j, key); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	return indexes, nil
+}
+
+// Argsort returns the indexes that would sort the column in ascending order, with missing values last.
+//
+// Values that compare equal keep their original relative order.
+func (c *Column) Argsort() []int {
+	indexes := make([]int, c.data.Len())
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	key := SortKey{Column: c.name}
+	slices.SortStableFunc(indexes, func(i, j int) int {
+		return compareRows(c.data, i, j, key)
+	})
+
+	return indexes
+}
+
+// compareRows compares the values at rows i and j of v according to key.
+func compareRows(v vector, i, j int, key SortKey) int {
+	iNull, jNull := v.IsNull(i), v.IsNull(j)
+	switch {
+	case iNull && jNull:
+		return 0
+	case iNull != jNull:
+		if iNull == key.NullsFirst {
+			return -1
+		}
+		return 1
+	}
+
+	compare := key.Compare
+	if compare == nil {
+		compare = compareValues
+	}
+
+	c := compare(v.At(i), v.At(j))
+	if key.Descending {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,127 @@
+package table
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSortBy(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"name":  {"d", "a", "c", "b", "e"},
+		"group": {"x", "y", "x", nil, "y"},
+		"score": {int64(2), 3.5, nil, int64(2), int64(1)},
+	}, "name", "group", "score")
+
+	tests := []struct {
+		name string
+		keys []SortKey
+		want []any
+	}{
+		{name: "ascending", keys: []SortKey{Asc("score")}, want: []any{"e", "d", "b", "a", "c"}},
+		{name: "descending", keys: []SortKey{Desc("score")}, want: []any{"a", "d", "b", "e", "c"}},
+		{name: "nulls first", keys: []SortKey{{Column: "score", NullsFirst: true}}, want: []any{"c", "e", "d", "b", "a"}},
+		{name: "descending nulls first", keys: []SortKey{{Column: "score", Descending: true, NullsFirst: true}}, want: []any{"c", "a", "d", "b", "e"}},
+		{name: "two keys", keys: []SortKey{Asc("group"), Desc("score")}, want: []any{"d", "c", "a", "e", "b"}},
+		{
+			name: "custom compare",
+			keys: []SortKey{{Column: "name", Compare: func(a, b any) int { return -strings.Compare(a.(string), b.(string)) }}},
+			want: []any{"e", "d", "c", "b", "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := tbl.SortBy(tt.keys...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertValues(t, sorted, "name", tt.want...)
+		})
+	}
+
+	// the original table is not modified
+	assertValues(t, tbl, "name", "d", "a", "c", "b", "e")
+}
+
+func TestSortByIsStable(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"k": {int64(1), int64(0), int64(1), int64(0)},
+		"i": {int64(0), int64(1), int64(2), int64(3)},
+	}, "k", "i")
+
+	sorted, err := tbl.SortBy(Asc("k"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, sorted, "i", int64(1), int64(3), int64(0), int64(2))
+}
+
+func TestSortByErrors(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{"a": {int64(1)}}, "a")
+
+	if _, err := tbl.SortBy(); err == nil {
+		t.Error("SortBy: expected an error without keys")
+	}
+	if _, err := tbl.SortBy(Asc("missing")); err == nil {
+		t.Error("SortBy: expected an error for a column that does not exist")
+	}
+}
+
+func TestSortByLargeIntegers(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"id": {int64(9007199254740993), int64(9007199254740992), int64(9007199254740994)},
+	}, "id")
+
+	sorted, err := tbl.SortBy(Asc("id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, sorted, "id", int64(9007199254740992), int64(9007199254740993), int64(9007199254740994))
+}
+
+func TestArgsort(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{"x": {int64(3), nil, int64(1), int64(2)}}, "x")
+
+	indexes, err := tbl.Argsort(Asc("x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 3, 0, 1}; !reflect.DeepEqual(indexes, want) {
+		t.Errorf("Argsort = %v, want %v", indexes, want)
+	}
+
+	if got := tbl.MustCol("x").Argsort(); !reflect.DeepEqual(got, indexes) {
+		t.Errorf("Column.Argsort = %v, want %v", got, indexes)
+	}
+
+	sorted, err := tbl.SelectRows(indexes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, sorted, "x", int64(1), int64(2), int64(3), nil)
+}
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		a, b any
+		want int
+	}{
+		{a: int64(2), b: 2.5, want: -1},
+		{a: 3, b: int64(3), want: 0},
+		{a: false, b: true, want: -1},
+		{a: "b", b: "a", want: 1},
+		{a: true, b: int64(0), want: -1},
+		{a: int64(9), b: "1", want: -1},
+		{a: int64(9007199254740993), b: int64(9007199254740992), want: 1},
+		{a: int64(math.MinInt64), b: math.MinInt64 + 1, want: -1},
+		{a: int64(1 << 62), b: float64(1 << 62), want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := compareValues(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareValues(%#v, %#v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
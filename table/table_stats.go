@@ -1,35 +1,45 @@
 package table
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-rowan/rowan/internal/parallel"
+)
 
 // Stats computes and displays descriptive statistics for all numeric columns in the table.
 //
 // The statistics include count, missing values, mean, standard deviation, minimum, quartiles (Q1, median, Q3), and maximum. Only columns containing numeric data are included in the output.
+// Columns are processed in parallel, see SetWorkers.
 //
 // The result is rendered as a table and printed directly to stdout.
 // This method does not return a value and does not modify the original table.
 // If the table is nil or empty, a message is printed instead.
 func (t *Table) Stats() {
+	if err := t.StatsContext(context.Background()); err != nil {
+		fmt.Println("failed to create stats table:", err)
+	}
+}
+
+// StatsContext is like Stats, but stops computing statistics and returns ctx.Err() when ctx is done before all columns have been processed.
+// Nothing is printed in that case.
+func (t *Table) StatsContext(ctx context.Context) error {
 	if t == nil || t.Len() == 0 {
 		fmt.Println("table is empty or nil")
+		return nil
 	}
 
 	statsColumns := []string{
 		"Column", "Count", "Missing", "Mean", "Std", "Min", "Q1", "Median", "Q3", "Max",
 	}
-	statsData := make(map[string][]any, len(statsColumns))
-	for _, c := range statsColumns {
-		statsData[c] = []any{}
-	}
 
-	for _, c := range t.Columns() {
-		col, err := t.Col(c)
-		if err != nil {
-			continue
-		}
+	columns := t.Columns()
+	rows := make([][]any, len(columns))
 
-		if !isNumericColumn(col) {
-			continue
+	err := parallel.For(ctx, len(columns), func(i int) error {
+		col, err := t.Col(columns[i])
+		if err != nil || !isNumericColumn(col) {
+			return nil
 		}
 
 		mean, _ := col.Mean()
@@ -40,23 +50,32 @@ func (t *Table) Stats() {
 		q3, _ := col.Q3()
 		max, _ := col.Max()
 
-		statsData["Column"] = append(statsData["Column"], c)
-		statsData["Count"] = append(statsData["Count"], col.Count())
-		statsData["Missing"] = append(statsData["Missing"], col.Missing())
-		statsData["Mean"] = append(statsData["Mean"], mean)
-		statsData["Std"] = append(statsData["Std"], std)
-		statsData["Min"] = append(statsData["Min"], min)
-		statsData["Q1"] = append(statsData["Q1"], q1)
-		statsData["Median"] = append(statsData["Median"], median)
-		statsData["Q3"] = append(statsData["Q3"], q3)
-		statsData["Max"] = append(statsData["Max"], max)
+		rows[i] = []any{columns[i], col.Count(), col.Missing(), mean, std, min, q1, median, q3, max}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	statsData := make(map[string][]any, len(statsColumns))
+	for _, c := range statsColumns {
+		statsData[c] = []any{}
+	}
+
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		for j, c := range statsColumns {
+			statsData[c] = append(statsData[c], row[j])
+		}
 	}
 
 	statsTable, err := New(statsData, statsColumns)
 	if err != nil {
-		fmt.Println("failed to create stats table:", err)
-		return
+		return err
 	}
 
 	statsTable.Display()
+	return nil
 }
@@ -0,0 +1,77 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStatsEmptyTable(t *testing.T) {
+	empty, err := mustNew(t, map[string][]any{"x": {1.0}}, "x").Filter("x > 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		table *Table
+	}{
+		{"nil", nil},
+		{"empty", empty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			out := captureStdout(t, func() { err = tt.table.StatsContext(context.Background()) })
+			if err != nil {
+				t.Fatalf("StatsContext: %v", err)
+			}
+			if out != "table is empty or nil\n" {
+				t.Errorf("output = %q", out)
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{
+		"name":  {"a", "b", "c", "d"},
+		"score": {1.0, 2.0, nil, 3.0},
+		"count": {int64(4), int64(2), int64(6), int64(8)},
+	}, "name", "score", "count")
+
+	for _, w := range []int{1, 4} {
+		prev := Workers()
+		SetWorkers(w)
+
+		out := captureStdout(t, tbl.Stats)
+		SetWorkers(prev)
+
+		if strings.Contains(out, "| name") {
+			t.Errorf("workers %d: non-numeric column in output:\n%s", w, out)
+		}
+		score := strings.Index(out, "| score")
+		count := strings.Index(out, "| count")
+		if score < 0 || count < score {
+			t.Errorf("workers %d: numeric columns missing or out of order:\n%s", w, out)
+		}
+	}
+}
+
+func TestStatsContextCanceled(t *testing.T) {
+	tbl := mustNew(t, map[string][]any{"x": {1.0, 2.0}}, "x")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var err error
+	out := captureStdout(t, func() { err = tbl.StatsContext(ctx) })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if out != "" {
+		t.Errorf("output = %q, want nothing", out)
+	}
+}
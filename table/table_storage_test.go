@@ -0,0 +1,136 @@
+package table
+
+import "testing"
+
+func storageTable(tb testing.TB) *Table {
+	values := make([]any, 200)
+	for i := range values {
+		if i%3 != 0 {
+			values[i] = int64(i)
+		}
+	}
+
+	return mustNew(tb, map[string][]any{
+		"x":    values,
+		"name": make([]any, 200),
+	}, "x", "name")
+}
+
+func TestSharedStorage(t *testing.T) {
+	tbl := storageTable(t)
+
+	selected, err := tbl.Select("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dropped, err := tbl.Drop("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapped, err := tbl.MapCol("name", func(any) any { return "n" })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		table *Table
+	}{
+		{"clone", tbl.Clone()},
+		{"select", selected},
+		{"drop", dropped},
+		{"map other column", mapped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.table.data["x"] != tbl.data["x"] {
+				t.Errorf("column x was copied")
+			}
+		})
+	}
+
+	col, err := tbl.Col("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.data != tbl.data["x"] {
+		t.Errorf("Col copied the column data")
+	}
+
+	values := col.Values()
+	values[1] = int64(-1)
+	if got := tbl.data["x"].At(1); got != int64(1) {
+		t.Errorf("modifying Values changed the table: x[1] = %v", got)
+	}
+}
+
+func TestCloneMutationsAreIndependent(t *testing.T) {
+	tbl := storageTable(t)
+	clone := tbl.Clone()
+
+	if err := clone.RenameColumn("x", "y"); err != nil {
+		t.Fatal(err)
+	}
+	if err := clone.ReplaceColumn("name", make([]any, 200)); err != nil {
+		t.Fatal(err)
+	}
+
+	assertColumns(t, tbl, "x", "name")
+	assertColumns(t, clone, "y", "name")
+	if clone.data["name"] == tbl.data["name"] {
+		t.Errorf("ReplaceColumn on the clone replaced the original column")
+	}
+}
+
+func TestRowSlices(t *testing.T) {
+	tbl := storageTable(t)
+
+	filtered, err := tbl.Filter("x is null or x >= 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected, err := tbl.SelectRows([]int{70, 71, 72, 73})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scattered, err := tbl.SelectRows([]int{3, 1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		table *Table
+		from  int
+		rows  []int
+	}{
+		{"first", tbl.First(130), 0, nil},
+		{"last", tbl.Last(5), 195, nil},
+		{"filter keeping every row", filtered, 0, nil},
+		{"contiguous rows", selected, 70, nil},
+		{"scattered rows", scattered, 0, []int{3, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.table.data["x"].(*typedVector[int64])
+			source := tbl.data["x"].(*typedVector[int64])
+
+			shared := len(v.values) > 0 && &v.values[0] == &source.values[tt.from]
+			if shared != (tt.rows == nil) {
+				t.Errorf("shares storage = %v, want %v", shared, tt.rows == nil)
+			}
+
+			for i := 0; i < tt.table.Len(); i++ {
+				row := tt.from + i
+				if tt.rows != nil {
+					row = tt.rows[i]
+				}
+				if got, want := v.At(i), source.At(row); got != want {
+					t.Fatalf("x[%d] = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
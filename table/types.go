@@ -0,0 +1,37 @@
+package table
+
+// Type identifies the kind of values held by a column.
+type Type int
+
+const (
+	// TypeUnknown is used for columns whose values could not be classified, such as columns holding only nil values.
+	TypeUnknown Type = iota
+	// TypeBool is a column of bool values.
+	TypeBool
+	// TypeInt is a column of int64 values.
+	TypeInt
+	// TypeFloat is a column of float64 values.
+	TypeFloat
+	// TypeString is a column of string values.
+	TypeString
+	// TypeTime is a column of time.Time values.
+	TypeTime
+)
+
+// String returns the lower-case name of the type, as shown by Overview.
+func (t Type) String() string {
+	switch t {
+	case TypeBool:
+		return "bool"
+	case TypeInt:
+		return "int"
+	case TypeFloat:
+		return "float"
+	case TypeString:
+		return "string"
+	case TypeTime:
+		return "time"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,262 @@
+package table
+
+import "time"
+
+// vector is the storage backing a single column.
+//
+// Columns whose values all share one of the supported Go types (int, int64, float64, bool, string or time.Time) are stored in a typedVector, which keeps the values unboxed and tracks missing values in a validity bitmap.
+// Any other column, such as one mixing several types, is stored in an anyVector.
+//
+// A vector is never modified once it has been built, so it can be shared by any number of tables and columns.
+// Methods that mutate a Table, such as ReplaceColumn or RenameColumn, replace its vectors or its column names, and never write to the vectors themselves.
+type vector interface {
+	// Len returns the number of values.
+	Len() int
+	// Type returns the type of the values, or TypeUnknown for columns that are not typed.
+	Type() Type
+	// At returns the value at index i, or nil if it is missing.
+	At(i int) any
+	// IsNull reports whether the value at index i is missing.
+	IsNull(i int) bool
+	// Take returns a new vector holding the values at the given indexes, in order. A negative index produces a missing value.
+	Take(indexes []int) vector
+	// slice returns a vector holding the values from index from up to index to, which shares the storage of the vector.
+	slice(from, to int) vector
+	// Values returns a copy of the values as a slice of any.
+	Values() []any
+}
+
+// typedVector stores the values of a column holding a single Go type.
+type typedVector[T any] struct {
+	typ    Type
+	values []T
+	valid  bitmap
+}
+
+func (v *typedVector[T]) Len() int {
+	return len(v.values)
+}
+
+func (v *typedVector[T]) Type() Type {
+	return v.typ
+}
+
+func (v *typedVector[T]) At(i int) any {
+	if !v.valid.get(i) {
+		return nil
+	}
+	return v.values[i]
+}
+
+func (v *typedVector[T]) IsNull(i int) bool {
+	return !v.valid.get(i)
+}
+
+func (v *typedVector[T]) Take(indexes []int) vector {
+	result := &typedVector[T]{
+		typ:    v.typ,
+		values: make([]T, len(indexes)),
+	}
+
+	for i, index := range indexes {
+		if index < 0 || !v.valid.get(index) {
+			result.valid.clear(i, len(indexes))
+			continue
+		}
+		result.values[i] = v.values[index]
+	}
+
+	return result
+}
+
+func (v *typedVector[T]) slice(from, to int) vector {
+	return &typedVector[T]{
+		typ:    v.typ,
+		values: v.values[from:to:to],
+		valid:  v.valid.slice(from, to),
+	}
+}
+
+func (v *typedVector[T]) Values() []any {
+	values := make([]any, len(v.values))
+	for i := range v.values {
+		values[i] = v.At(i)
+	}
+	return values
+}
+
+// anyVector stores the values of a column that cannot be typed, as boxed values.
+type anyVector struct {
+	values []any
+}
+
+func (v *anyVector) Len() int {
+	return len(v.values)
+}
+
+func (v *anyVector) Type() Type {
+	return TypeUnknown
+}
+
+func (v *anyVector) At(i int) any {
+	return v.values[i]
+}
+
+func (v *anyVector) IsNull(i int) bool {
+	return v.values[i] == nil
+}
+
+func (v *anyVector) Take(indexes []int) vector {
+	values := make([]any, len(indexes))
+	for i, index := range indexes {
+		if index >= 0 {
+			values[i] = v.values[index]
+		}
+	}
+	return &anyVector{values: values}
+}
+
+func (v *anyVector) slice(from, to int) vector {
+	return &anyVector{values: v.values[from:to:to]}
+}
+
+func (v *anyVector) Values() []any {
+	values := make([]any, len(v.values))
+	copy(values, v.values)
+	return values
+}
+
+// newVector builds the storage for the given values, choosing a typed vector when every non-nil value has the same supported type.
+//
+// The values are copied, so later changes to the slice do not affect the vector.
+func newVector(values []any) vector {
+	var first any
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+
+		if first == nil {
+			first = v
+			continue
+		}
+
+		if !sameType(first, v) {
+			return &anyVector{values: copyValues(values)}
+		}
+	}
+
+	switch first.(type) {
+	case int64:
+		return fillVector[int64](TypeInt, values)
+	case int:
+		return fillVector[int](TypeInt, values)
+	case float64:
+		return fillVector[float64](TypeFloat, values)
+	case bool:
+		return fillVector[bool](TypeBool, values)
+	case string:
+		return fillVector[string](TypeString, values)
+	case time.Time:
+		return fillVector[time.Time](TypeTime, values)
+	default:
+		return &anyVector{values: copyValues(values)}
+	}
+}
+
+func fillVector[T any](typ Type, values []any) *typedVector[T] {
+	v := &typedVector[T]{
+		typ:    typ,
+		values: make([]T, len(values)),
+	}
+
+	for i, value := range values {
+		if value == nil {
+			v.valid.clear(i, len(values))
+			continue
+		}
+		v.values[i] = value.(T)
+	}
+
+	return v
+}
+
+func sameType(a, b any) bool {
+	switch a.(type) {
+	case int64:
+		_, ok := b.(int64)
+		return ok
+	case int:
+		_, ok := b.(int)
+		return ok
+	case float64:
+		_, ok := b.(float64)
+		return ok
+	case bool:
+		_, ok := b.(bool)
+		return ok
+	case string:
+		_, ok := b.(string)
+		return ok
+	case time.Time:
+		_, ok := b.(time.Time)
+		return ok
+	default:
+		return false
+	}
+}
+
+func copyValues(values []any) []any {
+	result := make([]any, len(values))
+	copy(result, values)
+	return result
+}
+
+// emptyVector returns a vector with no values and the same storage type as v.
+func emptyVector(v vector) vector {
+	return v.Take(nil)
+}
+
+// bitmap tracks which values of a typedVector are present.
+//
+// A nil bitmap means every value is present. Bits are set for present values and cleared for missing ones.
+type bitmap []uint64
+
+func (b bitmap) get(i int) bool {
+	if b == nil {
+		return true
+	}
+	return b[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+// clear marks the value at index i as missing, allocating the bitmap for n values on first use.
+func (b *bitmap) clear(i, n int) {
+	if *b == nil {
+		words := make(bitmap, (n+63)/64)
+		for w := range words {
+			words[w] = ^uint64(0)
+		}
+		*b = words
+	}
+	(*b)[i/64] &^= 1 << (uint(i) % 64)
+}
+
+// slice returns the bitmap of the values from index from up to index to. It shares the words of b when from is aligned on a word.
+func (b bitmap) slice(from, to int) bitmap {
+	if b == nil {
+		return nil
+	}
+
+	if from%64 == 0 {
+		end := (to + 63) / 64
+		return b[from/64 : end : end]
+	}
+
+	var result bitmap
+	for i := from; i < to; i++ {
+		if !b.get(i) {
+			result.clear(i-from, to-from)
+		}
+	}
+	return result
+}
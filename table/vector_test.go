@@ -0,0 +1,117 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewVectorTypes(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		values []any
+		typ    Type
+		typed  bool
+	}{
+		{name: "int64", values: []any{int64(1), nil, int64(3)}, typ: TypeInt, typed: true},
+		{name: "int", values: []any{1, 2}, typ: TypeInt, typed: true},
+		{name: "float", values: []any{1.5, nil}, typ: TypeFloat, typed: true},
+		{name: "bool", values: []any{true, false}, typ: TypeBool, typed: true},
+		{name: "string", values: []any{"a", nil, "c"}, typ: TypeString, typed: true},
+		{name: "time", values: []any{now, nil}, typ: TypeTime, typed: true},
+		{name: "mixed", values: []any{int64(1), "a"}, typ: TypeUnknown},
+		{name: "int and int64", values: []any{1, int64(2)}, typ: TypeUnknown},
+		{name: "only nil", values: []any{nil, nil}, typ: TypeUnknown},
+		{name: "bytes", values: []any{[]byte("a")}, typ: TypeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newVector(tt.values)
+
+			if v.Type() != tt.typ {
+				t.Errorf("type = %v, want %v", v.Type(), tt.typ)
+			}
+			if _, isAny := v.(*anyVector); isAny == tt.typed {
+				t.Errorf("storage = %T, typed = %v", v, tt.typed)
+			}
+			if got := v.Values(); !reflect.DeepEqual(got, tt.values) {
+				t.Errorf("values = %#v, want %#v", got, tt.values)
+			}
+			for i, x := range tt.values {
+				if v.IsNull(i) != (x == nil) {
+					t.Errorf("IsNull(%d) = %v", i, v.IsNull(i))
+				}
+			}
+		})
+	}
+}
+
+func TestNewVectorCopiesValues(t *testing.T) {
+	values := []any{"a", "b"}
+	v := newVector(values)
+	values[0] = "changed"
+
+	if got := v.At(0); got != "a" {
+		t.Errorf("At(0) = %v, want a", got)
+	}
+}
+
+func TestVectorTakeAndSlice(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []any
+	}{
+		{name: "typed", values: []any{int64(10), nil, int64(30), int64(40)}},
+		{name: "any", values: []any{int64(10), nil, "x", 4.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newVector(tt.values)
+
+			taken := v.Take([]int{3, -1, 1, 0})
+			want := []any{tt.values[3], nil, nil, tt.values[0]}
+			if got := taken.Values(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Take = %#v, want %#v", got, want)
+			}
+
+			sliced := v.slice(1, 3)
+			if got := sliced.Values(); !reflect.DeepEqual(got, tt.values[1:3]) {
+				t.Errorf("slice = %#v, want %#v", got, tt.values[1:3])
+			}
+			if !sliced.IsNull(0) || sliced.IsNull(1) {
+				t.Errorf("slice validity is not shifted")
+			}
+
+			if empty := emptyVector(v); empty.Len() != 0 || empty.Type() != v.Type() {
+				t.Errorf("emptyVector = %d values of type %v", empty.Len(), empty.Type())
+			}
+		})
+	}
+}
+
+func TestBitmapAcrossWords(t *testing.T) {
+	values := make([]any, 130)
+	for i := range values {
+		if i%3 != 0 {
+			values[i] = int64(i)
+		}
+	}
+
+	v := newVector(values)
+	for i := range values {
+		if v.IsNull(i) != (i%3 == 0) {
+			t.Fatalf("IsNull(%d) = %v", i, v.IsNull(i))
+		}
+	}
+
+	sliced := v.slice(63, 130)
+	for i := 0; i < sliced.Len(); i++ {
+		if sliced.IsNull(i) != ((i+63)%3 == 0) {
+			t.Fatalf("slice IsNull(%d) = %v", i, sliced.IsNull(i))
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// ArrowIPCCompression is the compression codec used by WriteArrowIPC for the record batch buffers.
+type ArrowIPCCompression int
+
+const (
+	// ArrowIPCUncompressed disables compression. This is the default.
+	ArrowIPCUncompressed ArrowIPCCompression = iota
+	// ArrowIPCLz4 compresses with LZ4 frames.
+	ArrowIPCLz4
+	// ArrowIPCZstd compresses with Zstandard.
+	ArrowIPCZstd
+)
+
+func (c ArrowIPCCompression) option() (ipc.Option, error) {
+	switch c {
+	case ArrowIPCUncompressed:
+		return nil, nil
+	case ArrowIPCLz4:
+		return ipc.WithLZ4(), nil
+	case ArrowIPCZstd:
+		return ipc.WithZstd(), nil
+	default:
+		return nil, fmt.Errorf("table: unknown arrow ipc compression %d", c)
+	}
+}
+
+// WriteArrowIPCOption configures how WriteArrowIPC writes a table.
+type WriteArrowIPCOption func(*writeArrowIPCOptions)
+
+type writeArrowIPCOptions struct {
+	stream      bool
+	compression ArrowIPCCompression
+}
+
+// WithArrowIPCStream returns a WriteArrowIPCOption that writes the stream format instead of the file format.
+//
+// The stream format has no footer, so it can be consumed sequentially, but it does not allow random access to the record batches.
+func WithArrowIPCStream() WriteArrowIPCOption {
+	return func(o *writeArrowIPCOptions) {
+		o.stream = true
+	}
+}
+
+// WithArrowIPCCompression returns a WriteArrowIPCOption that sets the compression codec of the record batch buffers. The default is ArrowIPCUncompressed.
+func WithArrowIPCCompression(c ArrowIPCCompression) WriteArrowIPCOption {
+	return func(o *writeArrowIPCOptions) {
+		o.compression = c
+	}
+}
+
+// WriteArrowIPC writes the Table to an Arrow IPC file specified by filename.
+//
+// By default the file format, also known as Feather version 2, is written; WithArrowIPCStream selects the stream format.
+// Columns are converted to Arrow arrays like ToArrow does, and written as a single record batch.
+//
+// Like WriteCSV, the data is written to a temporary file that is renamed to filename on success, so no partial file is left behind on error.
+func (t *Table) WriteArrowIPC(filename string, argOpts ...WriteArrowIPCOption) error {
+	var opts writeArrowIPCOptions
+	for _, opt := range argOpts {
+		opt(&opts)
+	}
+
+	if len(t.columns) == 0 {
+		return fmt.Errorf("table: no columns to write")
+	}
+
+	compression, err := opts.compression.option()
+	if err != nil {
+		return err
+	}
+
+	rec, err := t.arrowRecord(memory.DefaultAllocator)
+	if err != nil {
+		return err
+	}
+	defer rec.Release()
+
+	ipcOpts := []ipc.Option{ipc.WithSchema(rec.Schema()), ipc.WithAllocator(memory.DefaultAllocator)}
+	if compression != nil {
+		ipcOpts = append(ipcOpts, compression)
+	}
+
+	return writeFile(filename, func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+
+		var iw interface {
+			Write(rec arrow.RecordBatch) error
+			Close() error
+		}
+		if opts.stream {
+			iw = ipc.NewWriter(bw, ipcOpts...)
+		} else {
+			fw, err := ipc.NewFileWriter(bw, ipcOpts...)
+			if err != nil {
+				return fmt.Errorf("table: failed creating arrow ipc writer: %w", err)
+			}
+			iw = fw
+		}
+
+		if err := iw.Write(rec); err != nil {
+			iw.Close()
+			return fmt.Errorf("table: failed writing arrow ipc data: %w", err)
+		}
+
+		if err := iw.Close(); err != nil {
+			return fmt.Errorf("table: failed closing arrow ipc writer: %w", err)
+		}
+
+		if err := bw.Flush(); err != nil {
+			return fmt.Errorf("table: failed flushing writer: %w", err)
+		}
+		return nil
+	})
+}
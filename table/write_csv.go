@@ -3,10 +3,28 @@ package table
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"time"
 )
 
+// WriteCSVOption configures how WriteCSV writes a table.
+type WriteCSVOption func(*writeCSVOptions)
+
+type writeCSVOptions struct {
+	null string
+}
+
+// WithCSVNull returns a WriteCSVOption that sets the text written for missing values, such as "NA" or "NULL".
+//
+// By default missing values are written as empty strings.
+func WithCSVNull(s string) WriteCSVOption {
+	return func(o *writeCSVOptions) {
+		o.null = s
+	}
+}
+
 // WriteCSV writes the Table to a CSV file specified by filename.
 //
 // The function creates a temporary file in the same directory as filename and writes all table data to it. If writing succeeds, the temporary file is renamed to the target filename. If any error occurs during writing or flushing, the temporary file is removed to avoid leaving a partial/corrupt file.
@@ -16,7 +34,8 @@ import (
 // The CSV output format:
 //   - The first row contains the column headers in the order defined in the Table.
 //   - Each subsequent row contains the corresponding values of the Table as strings.
-//   - Missing values are written as empty strings.
+//   - Time values are written in RFC 3339 format.
+//   - Missing values are written as empty strings, or as the text set with WithCSVNull.
 //
 // Error conditions:
 //   - if the table has no data or no columns
@@ -24,11 +43,53 @@ import (
 //   - if writing the header or any row fails
 //   - if flushing the writer fails
 //   - if closing or renaming the temporary file fails
-func (t *Table) WriteCSV(filename string) error {
+func (t *Table) WriteCSV(filename string, argOpts ...WriteCSVOption) error {
+	var opts writeCSVOptions
+	for _, opt := range argOpts {
+		opt(&opts)
+	}
+
 	if t.length == 0 || len(t.columns) == 0 {
 		return fmt.Errorf("table: no data to write")
 	}
 
+	return writeFile(filename, func(w io.Writer) error {
+		writer := csv.NewWriter(w)
+
+		// header
+		if err := writer.Write(t.Columns()); err != nil {
+			return fmt.Errorf("table: failed writing header: %w", err)
+		}
+
+		// rows
+		for i := 0; i < t.length; i++ {
+			row := make([]string, len(t.columns))
+
+			for j, column := range t.columns {
+				values := t.data[column]
+				if i < values.Len() && !values.IsNull(i) {
+					row[j] = csvValue(values.At(i))
+				} else {
+					row[j] = opts.null
+				}
+			}
+
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("table: failed writing row %d: %w", i, err)
+			}
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("table: failed flushing writer: %w", err)
+		}
+		return nil
+	})
+}
+
+// writeFile calls write with a temporary file created in the same directory as filename, and renames it to filename if write succeeds.
+// The temporary file is removed if any step fails, so that no partial file is left behind.
+func writeFile(filename string, write func(w io.Writer) error) error {
 	dir := filepath.Dir(filename)
 	tempFile, err := os.CreateTemp(dir, "*.tmp")
 	if err != nil {
@@ -43,34 +104,8 @@ func (t *Table) WriteCSV(filename string) error {
 		}
 	}()
 
-	writer := csv.NewWriter(tempFile)
-
-	// header
-	if err := writer.Write(t.Columns()); err != nil {
-		return fmt.Errorf("table: failed writing header: %w", err)
-	}
-
-	// rows
-	for i := 0; i < t.length; i++ {
-		row := make([]string, len(t.columns))
-
-		for j, column := range t.columns {
-			values := t.data[column]
-			if i < len(values) {
-				row[j] = fmt.Sprint(values[i])
-			} else {
-				row[j] = ""
-			}
-		}
-
-		if err := writer.Write(row); err != nil {
-			return fmt.Errorf("table: failed writing row %d: %w", i, err)
-		}
-	}
-
-	writer.Flush()
-	if err := writer.Error(); err != nil {
-		return fmt.Errorf("table: failed flushing writer: %w", err)
+	if err := write(tempFile); err != nil {
+		return err
 	}
 
 	if err := tempFile.Close(); err != nil {
@@ -83,3 +118,10 @@ func (t *Table) WriteCSV(filename string) error {
 
 	return nil
 }
+
+func csvValue(value any) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,226 @@
+package table
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// WriteJSONOption configures how WriteJSON writes a table.
+type WriteJSONOption func(*writeJSONOptions)
+
+type writeJSONOptions struct {
+	columns bool
+}
+
+// WithJSONColumns returns a WriteJSONOption that writes the table as an object mapping every column name to the array of its values,
+// such as {"a": [1, 2], "b": ["x", null]}, instead of an array of records.
+func WithJSONColumns() WriteJSONOption {
+	return func(o *writeJSONOptions) {
+		o.columns = true
+	}
+}
+
+// MarshalJSON encodes the Table as a JSON array of records, one object per row with the keys in column order, such as [{"a": 1, "b": "x"}, {"a": 2, "b": null}].
+//
+// Values are encoded as described in WriteJSON. It implements json.Marshaler, so a Table can be passed directly to json.Marshal or json.Encoder.
+func (t *Table) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.encodeRecords(&buf, "[", ",", "]"); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteJSON writes the Table to a JSON file specified by filename.
+//
+// By default the file holds an array of records, one object per row with the keys in column order, and one record per line. WithJSONColumns writes an object of columns instead.
+// Both layouts are read back by FromJSON. A table without rows is only read back from the object of columns, since an empty array of records holds no column names.
+//
+// Values are written with their types:
+//   - integers and booleans as JSON numbers and booleans
+//   - floating point numbers as JSON numbers with a fractional part or an exponent, such as 1.0, so that they are read back as floats. NaN and infinities are written as null.
+//   - time values as strings in RFC 3339 format
+//   - missing values as null
+//   - other values with json.Marshal
+//
+// Like WriteCSV, the data is written to a temporary file that is renamed to filename on success, so no partial file is left behind on error.
+func (t *Table) WriteJSON(filename string, argOpts ...WriteJSONOption) error {
+	var opts writeJSONOptions
+	for _, opt := range argOpts {
+		opt(&opts)
+	}
+
+	return writeFile(filename, func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+
+		var err error
+		if opts.columns {
+			err = t.encodeColumns(bw)
+		} else {
+			err = t.encodeRecords(bw, "[\n", ",\n", "\n]\n")
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := bw.Flush(); err != nil {
+			return fmt.Errorf("table: failed flushing writer: %w", err)
+		}
+		return nil
+	})
+}
+
+// WriteJSONL writes the Table to a JSON Lines file specified by filename, one record per line with the keys in column order.
+//
+// Values are written as described in WriteJSON, and the file is written like WriteJSON does. The file is read back by FromJSONL,
+// except for a table without rows: its file is empty and holds no column names, so FromJSONL returns an error.
+func (t *Table) WriteJSONL(filename string) error {
+	return writeFile(filename, func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+
+		end := "\n"
+		if t.length == 0 {
+			end = ""
+		}
+		if err := t.encodeRecords(bw, "", "\n", end); err != nil {
+			return err
+		}
+
+		if err := bw.Flush(); err != nil {
+			return fmt.Errorf("table: failed flushing writer: %w", err)
+		}
+		return nil
+	})
+}
+
+// encodeRecords writes one object per row, separated by sep, between open and close.
+func (t *Table) encodeRecords(w io.Writer, open, sep, close string) error {
+	keys := make([][]byte, len(t.columns))
+	for j, c := range t.columns {
+		keys[j] = appendJSONString(nil, c)
+	}
+
+	buf := []byte(open)
+	for i := 0; i < t.length; i++ {
+		if i > 0 {
+			buf = append(buf, sep...)
+		}
+
+		buf = append(buf, '{')
+		for j, c := range t.columns {
+			if j > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, keys[j]...)
+			buf = append(buf, ':')
+
+			var err error
+			if buf, err = appendJSONValue(buf, t.data[c], i); err != nil {
+				return fmt.Errorf("table: failed writing row %d column %s: %w", i, c, err)
+			}
+		}
+		buf = append(buf, '}')
+
+		if len(buf) >= 64*1024 {
+			if _, err := w.Write(buf); err != nil {
+				return fmt.Errorf("table: failed writing row %d: %w", i, err)
+			}
+			buf = buf[:0]
+		}
+	}
+	buf = append(buf, close...)
+
+	if _, err := w.Write(buf); err != nil {
+		return fmt.Errorf("table: failed writing rows: %w", err)
+	}
+	return nil
+}
+
+// encodeColumns writes an object mapping every column name to the array of its values, one column per line.
+func (t *Table) encodeColumns(w io.Writer) error {
+	buf := []byte("{")
+	for j, c := range t.columns {
+		if j > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, "\n"...)
+		buf = appendJSONString(buf, c)
+		buf = append(buf, ":["...)
+
+		values := t.data[c]
+		for i := 0; i < t.length; i++ {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+
+			var err error
+			if buf, err = appendJSONValue(buf, values, i); err != nil {
+				return fmt.Errorf("table: failed writing row %d column %s: %w", i, c, err)
+			}
+		}
+		buf = append(buf, ']')
+
+		if _, err := w.Write(buf); err != nil {
+			return fmt.Errorf("table: failed writing column %s: %w", c, err)
+		}
+		buf = buf[:0]
+	}
+	buf = append(buf, "\n}\n"...)
+
+	if _, err := w.Write(buf); err != nil {
+		return fmt.Errorf("table: failed writing columns: %w", err)
+	}
+	return nil
+}
+
+func appendJSONValue(buf []byte, values vector, i int) ([]byte, error) {
+	if values.IsNull(i) {
+		return append(buf, "null"...), nil
+	}
+
+	switch v := values.At(i).(type) {
+	case int64:
+		return strconv.AppendInt(buf, v, 10), nil
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10), nil
+	case float64:
+		return appendJSONFloat(buf, v), nil
+	case bool:
+		return strconv.AppendBool(buf, v), nil
+	case string:
+		return appendJSONString(buf, v), nil
+	case time.Time:
+		return appendJSONString(buf, v.Format(time.RFC3339Nano)), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return append(buf, b...), nil
+	}
+}
+
+// appendJSONFloat writes f with a fractional part or an exponent, so that it is not read back as an integer.
+func appendJSONFloat(buf []byte, f float64) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return append(buf, "null"...)
+	}
+
+	start := len(buf)
+	buf = strconv.AppendFloat(buf, f, 'g', -1, 64)
+	if !bytes.ContainsAny(buf[start:], ".e") {
+		buf = append(buf, ".0"...)
+	}
+	return buf
+}
+
+func appendJSONString(buf []byte, s string) []byte {
+	b, _ := json.Marshal(s)
+	return append(buf, b...)
+}
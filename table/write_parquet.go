@@ -0,0 +1,147 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// ParquetCompression is the compression codec used by WriteParquet.
+type ParquetCompression int
+
+const (
+	// ParquetSnappy compresses with Snappy. This is the default.
+	ParquetSnappy ParquetCompression = iota
+	// ParquetUncompressed disables compression.
+	ParquetUncompressed
+	// ParquetGzip compresses with gzip.
+	ParquetGzip
+	// ParquetZstd compresses with Zstandard.
+	ParquetZstd
+	// ParquetBrotli compresses with Brotli.
+	ParquetBrotli
+	// ParquetLz4 compresses with LZ4, using the LZ4_RAW codec.
+	ParquetLz4
+)
+
+func (c ParquetCompression) codec() (compress.Compression, error) {
+	switch c {
+	case ParquetSnappy:
+		return compress.Codecs.Snappy, nil
+	case ParquetUncompressed:
+		return compress.Codecs.Uncompressed, nil
+	case ParquetGzip:
+		return compress.Codecs.Gzip, nil
+	case ParquetZstd:
+		return compress.Codecs.Zstd, nil
+	case ParquetBrotli:
+		return compress.Codecs.Brotli, nil
+	case ParquetLz4:
+		return compress.Codecs.Lz4Raw, nil
+	default:
+		return 0, fmt.Errorf("table: unknown parquet compression %d", c)
+	}
+}
+
+// defaultParquetRowGroupSize is the default maximum number of rows of a row group.
+const defaultParquetRowGroupSize = 128 * 1024
+
+// WriteParquetOption configures how WriteParquet writes a table.
+type WriteParquetOption func(*writeParquetOptions)
+
+type writeParquetOptions struct {
+	compression  ParquetCompression
+	rowGroupSize int
+}
+
+// WithParquetCompression returns a WriteParquetOption that sets the compression codec of the data pages. The default is ParquetSnappy.
+func WithParquetCompression(c ParquetCompression) WriteParquetOption {
+	return func(o *writeParquetOptions) {
+		o.compression = c
+	}
+}
+
+// WithParquetRowGroupSize returns a WriteParquetOption that sets the maximum number of rows of a row group. The default is 131072 rows.
+//
+// Smaller row groups let readers skip more data using the statistics of each row group, larger row groups compress better.
+func WithParquetRowGroupSize(rows int) WriteParquetOption {
+	return func(o *writeParquetOptions) {
+		o.rowGroupSize = rows
+	}
+}
+
+// WriteParquet writes the Table to a Parquet file specified by filename.
+//
+// Every column is written as an optional Parquet column, so missing values are preserved:
+//   - integer columns as INT64
+//   - floating point columns as DOUBLE
+//   - boolean columns as BOOLEAN
+//   - string columns as BYTE_ARRAY annotated as UTF8 strings
+//   - time columns as INT64 annotated as UTC timestamps with nanosecond precision
+//
+// Columns of mixed types are written as DOUBLE when all their values are numbers, as BYTE_ARRAY when they are all []byte, and as strings formatted like WriteCSV does otherwise.
+// Columns with only missing values are written with the Parquet null type.
+//
+// Like WriteCSV, the data is written to a temporary file that is renamed to filename on success, so no partial file is left behind on error.
+func (t *Table) WriteParquet(filename string, argOpts ...WriteParquetOption) error {
+	opts := writeParquetOptions{
+		compression:  ParquetSnappy,
+		rowGroupSize: defaultParquetRowGroupSize,
+	}
+	for _, opt := range argOpts {
+		opt(&opts)
+	}
+
+	if opts.rowGroupSize < 1 {
+		return fmt.Errorf("table: parquet row group size must be positive, got %d", opts.rowGroupSize)
+	}
+	if len(t.columns) == 0 {
+		return fmt.Errorf("table: no columns to write")
+	}
+
+	codec, err := opts.compression.codec()
+	if err != nil {
+		return err
+	}
+
+	rec, err := t.arrowRecord(memory.DefaultAllocator)
+	if err != nil {
+		return err
+	}
+	defer rec.Release()
+
+	props := parquet.NewWriterProperties(
+		parquet.WithCompression(codec),
+		parquet.WithMaxRowGroupLength(int64(opts.rowGroupSize)),
+	)
+	arrowProps := pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema())
+
+	return writeFile(filename, func(w io.Writer) error {
+		// the parquet writer closes its output when it is an io.Closer, the buffer keeps the file open
+		bw := bufio.NewWriter(w)
+
+		fw, err := pqarrow.NewFileWriter(rec.Schema(), bw, props, arrowProps)
+		if err != nil {
+			return fmt.Errorf("table: failed creating parquet writer: %w", err)
+		}
+
+		if err := fw.Write(rec); err != nil {
+			fw.Close()
+			return fmt.Errorf("table: failed writing parquet data: %w", err)
+		}
+
+		if err := fw.Close(); err != nil {
+			return fmt.Errorf("table: failed closing parquet writer: %w", err)
+		}
+
+		if err := bw.Flush(); err != nil {
+			return fmt.Errorf("table: failed flushing writer: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,339 @@
+package table
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-rowan/rowan/internal/numeric"
+)
+
+// SQLDialect selects the SQL syntax and column types used by WriteSQL.
+type SQLDialect int
+
+const (
+	// SQLGeneric uses ? placeholders, double-quoted identifiers and standard column types. It suits SQLite and most other databases. This is the default.
+	SQLGeneric SQLDialect = iota
+	// SQLPostgres uses $1, $2, ... placeholders and PostgreSQL column types.
+	SQLPostgres
+	// SQLMySQL uses ? placeholders, backquoted identifiers and MySQL column types.
+	SQLMySQL
+)
+
+// sqlColumnType is the type of a column created by WriteSQL, independent of the dialect.
+type sqlColumnType int
+
+const (
+	sqlInt sqlColumnType = iota
+	sqlFloat
+	sqlBool
+	sqlText
+	sqlBytes
+	sqlTime
+)
+
+func (d SQLDialect) check() error {
+	switch d {
+	case SQLGeneric, SQLPostgres, SQLMySQL:
+		return nil
+	default:
+		return fmt.Errorf("table: unknown sql dialect %d", d)
+	}
+}
+
+func (d SQLDialect) quote(name string) string {
+	q := `"`
+	if d == SQLMySQL {
+		q = "`"
+	}
+	return q + strings.ReplaceAll(name, q, q+q) + q
+}
+
+// quoteTable quotes a table name, which may be qualified by a schema name.
+func (d SQLDialect) quoteTable(name string) string {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = d.quote(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+func (d SQLDialect) placeholder(n int) string {
+	if d == SQLPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// maxParams is the number of parameters of a statement WriteSQL stays within.
+func (d SQLDialect) maxParams() int {
+	if d == SQLGeneric {
+		// the limit of SQLite before version 3.32
+		return 999
+	}
+	return 65535
+}
+
+func (d SQLDialect) columnType(c sqlColumnType) string {
+	switch d {
+	case SQLPostgres:
+		return [...]string{"BIGINT", "DOUBLE PRECISION", "BOOLEAN", "TEXT", "BYTEA", "TIMESTAMPTZ"}[c]
+	case SQLMySQL:
+		return [...]string{"BIGINT", "DOUBLE", "BOOLEAN", "LONGTEXT", "LONGBLOB", "DATETIME(6)"}[c]
+	default:
+		return [...]string{"BIGINT", "DOUBLE PRECISION", "BOOLEAN", "TEXT", "BLOB", "TIMESTAMP"}[c]
+	}
+}
+
+// SQLWriteMode specifies what WriteSQL does with an existing database table.
+type SQLWriteMode int
+
+const (
+	// SQLCreate creates the database table, and fails if it already exists. This is the default.
+	SQLCreate SQLWriteMode = iota
+	// SQLAppend inserts the rows into an existing database table, whose columns are matched by name.
+	SQLAppend
+	// SQLReplace drops the database table if it exists, and creates it again.
+	SQLReplace
+)
+
+// defaultSQLBatchSize is the default maximum number of rows inserted by a single statement.
+const defaultSQLBatchSize = 1000
+
+// WriteSQLOption configures how WriteSQL writes a table.
+type WriteSQLOption func(*writeSQLOptions)
+
+type writeSQLOptions struct {
+	mode      SQLWriteMode
+	dialect   SQLDialect
+	batchSize int
+}
+
+// WithSQLMode returns a WriteSQLOption that sets what happens to an existing database table. The default is SQLCreate.
+func WithSQLMode(mode SQLWriteMode) WriteSQLOption {
+	return func(o *writeSQLOptions) {
+		o.mode = mode
+	}
+}
+
+// WithSQLDialect returns a WriteSQLOption that sets the SQL dialect of the database. The default is SQLGeneric.
+func WithSQLDialect(d SQLDialect) WriteSQLOption {
+	return func(o *writeSQLOptions) {
+		o.dialect = d
+	}
+}
+
+// WithSQLBatchSize returns a WriteSQLOption that sets the maximum number of rows inserted by a single INSERT statement. The default is 1000 rows.
+//
+// The batch size is lowered when needed to keep the number of parameters of a statement within the limit of the database.
+func WithSQLBatchSize(rows int) WriteSQLOption {
+	return func(o *writeSQLOptions) {
+		o.batchSize = rows
+	}
+}
+
+// WriteSQL writes the rows of the Table into the database table tableName, using multi-row INSERT statements.
+//
+// With SQLCreate and SQLReplace, the database table is created with one nullable column per column of the Table:
+//   - integer columns as BIGINT
+//   - floating point columns as DOUBLE PRECISION, or DOUBLE with SQLMySQL
+//   - boolean columns as BOOLEAN
+//   - string columns as TEXT, or LONGTEXT with SQLMySQL
+//   - time columns as TIMESTAMP, TIMESTAMPTZ with SQLPostgres, or DATETIME(6) with SQLMySQL
+//
+// Columns of mixed types are written as floating point columns when all their values are numbers, as binary columns when they are all []byte,
+// and as strings formatted like WriteCSV does otherwise. Missing values are written as NULL.
+//
+// The statements run in a single transaction, so the database table is left unchanged if writing fails or ctx is cancelled,
+// except with databases that commit schema changes implicitly, such as MySQL.
+func (t *Table) WriteSQL(ctx context.Context, db *sql.DB, tableName string, argOpts ...WriteSQLOption) error {
+	opts := writeSQLOptions{
+		mode:      SQLCreate,
+		dialect:   SQLGeneric,
+		batchSize: defaultSQLBatchSize,
+	}
+	for _, opt := range argOpts {
+		opt(&opts)
+	}
+
+	if err := opts.dialect.check(); err != nil {
+		return err
+	}
+	if opts.mode < SQLCreate || opts.mode > SQLReplace {
+		return fmt.Errorf("table: unknown sql write mode %d", opts.mode)
+	}
+	if opts.batchSize < 1 {
+		return fmt.Errorf("table: sql batch size must be positive, got %d", opts.batchSize)
+	}
+	if tableName == "" {
+		return fmt.Errorf("table: empty sql table name")
+	}
+	if len(t.columns) == 0 {
+		return fmt.Errorf("table: no columns to write")
+	}
+
+	types := make([]sqlColumnType, len(t.columns))
+	values := make([]func(i int) any, len(t.columns))
+	for j, c := range t.columns {
+		types[j], values[j] = sqlColumn(t.data[c])
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("table: failed starting sql transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	d := opts.dialect
+	name := d.quoteTable(tableName)
+
+	if opts.mode == SQLReplace {
+		if _, err := tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+name); err != nil {
+			return fmt.Errorf("table: failed dropping sql table %s: %w", tableName, err)
+		}
+	}
+
+	if opts.mode != SQLAppend {
+		defs := make([]string, len(t.columns))
+		for j, c := range t.columns {
+			defs[j] = d.quote(c) + " " + d.columnType(types[j])
+		}
+
+		if _, err := tx.ExecContext(ctx, "CREATE TABLE "+name+" ("+strings.Join(defs, ", ")+")"); err != nil {
+			return fmt.Errorf("table: failed creating sql table %s: %w", tableName, err)
+		}
+	}
+
+	batch := max(1, min(opts.batchSize, d.maxParams()/len(t.columns)))
+	if err := t.insertSQL(ctx, tx, d, name, batch, values); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("table: failed committing sql transaction: %w", err)
+	}
+	return nil
+}
+
+// insertSQL inserts the rows in batches of up to batch rows, preparing the statement of full batches once.
+func (t *Table) insertSQL(ctx context.Context, tx *sql.Tx, d SQLDialect, name string, batch int, values []func(i int) any) error {
+	quoted := make([]string, len(t.columns))
+	for j, c := range t.columns {
+		quoted[j] = d.quote(c)
+	}
+	prefix := "INSERT INTO " + name + " (" + strings.Join(quoted, ", ") + ") VALUES "
+
+	statement := func(rows int) string {
+		var b strings.Builder
+		b.WriteString(prefix)
+		for i := 0; i < rows; i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteByte('(')
+			for j := range values {
+				if j > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(d.placeholder(i*len(values) + j + 1))
+			}
+			b.WriteByte(')')
+		}
+		return b.String()
+	}
+
+	var full *sql.Stmt
+	if t.length >= batch {
+		var err error
+		if full, err = tx.PrepareContext(ctx, statement(batch)); err != nil {
+			return fmt.Errorf("table: failed preparing sql insert: %w", err)
+		}
+		defer full.Close()
+	}
+
+	args := make([]any, 0, batch*len(values))
+	for from := 0; from < t.length; from += batch {
+		to := min(from+batch, t.length)
+
+		args = args[:0]
+		for i := from; i < to; i++ {
+			for _, value := range values {
+				args = append(args, value(i))
+			}
+		}
+
+		var err error
+		if to-from == batch {
+			_, err = full.ExecContext(ctx, args...)
+		} else {
+			_, err = tx.ExecContext(ctx, statement(to-from), args...)
+		}
+		if err != nil {
+			return fmt.Errorf("table: failed inserting rows %d to %d: %w", from, to-1, err)
+		}
+	}
+
+	return nil
+}
+
+// sqlColumn returns the database column type of v, and a function returning the value of row i as a query argument.
+func sqlColumn(v vector) (sqlColumnType, func(i int) any) {
+	value := func(f func(x any) any) func(i int) any {
+		return func(i int) any {
+			if v.IsNull(i) {
+				return nil
+			}
+			return f(v.At(i))
+		}
+	}
+
+	switch v.Type() {
+	case TypeInt:
+		return sqlInt, value(func(x any) any {
+			if i, ok := x.(int); ok {
+				return int64(i)
+			}
+			return x
+		})
+	case TypeFloat:
+		return sqlFloat, value(func(x any) any { return x })
+	case TypeBool:
+		return sqlBool, value(func(x any) any { return x })
+	case TypeString:
+		return sqlText, value(func(x any) any { return x })
+	case TypeTime:
+		return sqlTime, value(func(x any) any { return x })
+	}
+
+	numbers, bytes, present := true, true, false
+	for i := 0; i < v.Len(); i++ {
+		if v.IsNull(i) {
+			continue
+		}
+		present = true
+
+		x := v.At(i)
+		if _, ok := numeric.ToFloat64(x); !ok {
+			numbers = false
+		}
+		if _, ok := x.([]byte); !ok {
+			bytes = false
+		}
+	}
+
+	switch {
+	case !present:
+		return sqlText, value(func(x any) any { return nil })
+	case numbers:
+		return sqlFloat, value(func(x any) any {
+			f, _ := numeric.ToFloat64(x)
+			return f
+		})
+	case bytes:
+		return sqlBytes, value(func(x any) any { return x })
+	default:
+		return sqlText, value(func(x any) any { return csvValue(x) })
+	}
+}
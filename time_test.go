@@ -0,0 +1,53 @@
+package rowan
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVTimeInference(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  []CSVOption
+		want  time.Time
+	}{
+		{name: "date", input: "t\n2024-01-31\n", want: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "datetime", input: "t\n2024-01-31 08:30:00\n", want: time.Date(2024, 1, 31, 8, 30, 0, 0, time.UTC)},
+		{name: "rfc3339", input: "t\n2024-01-31T08:30:00Z\n", want: time.Date(2024, 1, 31, 8, 30, 0, 0, time.UTC)},
+		{
+			name:  "custom layout",
+			input: "t\n31/01/2024\n",
+			opts:  []CSVOption{WithTimeLayout("02/01/2006")},
+			want:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl, err := FromCSVReader(strings.NewReader(tt.input), tt.opts...)
+			if err != nil {
+				t.Fatalf("FromCSVReader: %v", err)
+			}
+
+			col := tbl.MustCol("t")
+			if col.Type() != TypeTime {
+				t.Fatalf("type = %v, want %v", col.Type(), TypeTime)
+			}
+			if got, ok := col.At(0).(time.Time); !ok || !got.Equal(tt.want) {
+				t.Errorf("value = %v, want %v", col.At(0), tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVNumbersAreNotTimes(t *testing.T) {
+	tbl, err := FromCSVReader(strings.NewReader("t\n20240131\n"))
+	if err != nil {
+		t.Fatalf("FromCSVReader: %v", err)
+	}
+	if got := tbl.MustCol("t").Type(); got != TypeInt {
+		t.Errorf("type = %v, want %v", got, TypeInt)
+	}
+}
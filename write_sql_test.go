@@ -0,0 +1,215 @@
+package rowan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-rowan/rowan/table"
+	"modernc.org/sqlite"
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+// openSQLite opens a SQLite database in a temporary file, closed when the test ends.
+func openSQLite(tb testing.TB) *sql.DB {
+	tb.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(tb.TempDir(), "test.db"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	return db
+}
+
+func queryTable(tb testing.TB, db *sql.DB, query string) *Table {
+	tb.Helper()
+
+	tbl, err := FromQuery(context.Background(), db, query)
+	if err != nil {
+		tb.Fatalf("FromQuery: %v", err)
+	}
+	return tbl
+}
+
+func TestWriteSQLCreate(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+	tbl := typedTable(t)
+
+	if err := tbl.WriteSQL(ctx, db, "events"); err != nil {
+		t.Fatalf("WriteSQL: %v", err)
+	}
+	assertSameTable(t, queryTable(t, db, "SELECT * FROM events"), tbl)
+
+	err := tbl.WriteSQL(ctx, db, "events")
+	if err == nil || !strings.Contains(err.Error(), "failed creating sql table events") {
+		t.Fatalf("second WriteSQL: err = %v, want a create error", err)
+	}
+	if n := queryTable(t, db, "SELECT COUNT(*) AS n FROM events").MustCol("n").At(0); n != int64(tbl.Len()) {
+		t.Errorf("events holds %v rows after the failed create, want %d", n, tbl.Len())
+	}
+}
+
+func TestWriteSQLAppend(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+	tbl := typedTable(t)
+
+	if _, err := db.Exec(`CREATE TABLE events (at TIMESTAMP, id BIGINT, name TEXT, ok BOOLEAN, score DOUBLE PRECISION, extra TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, batch := range []int{2, 1000} {
+		if err := tbl.WriteSQL(ctx, db, "events", table.WithSQLMode(table.SQLAppend), table.WithSQLBatchSize(batch)); err != nil {
+			t.Fatalf("WriteSQL with batches of %d rows: %v", batch, err)
+		}
+	}
+
+	got := queryTable(t, db, "SELECT id, score, name, ok, at FROM events")
+	want, err := table.Concat(tbl, tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameTable(t, got, want)
+
+	if extra := queryTable(t, db, "SELECT COUNT(extra) AS n FROM events").MustCol("n").At(0); extra != int64(0) {
+		t.Errorf("extra column holds %v values, want 0", extra)
+	}
+}
+
+func TestWriteSQLReplace(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+
+	if _, err := db.Exec(`CREATE TABLE events (other TEXT); INSERT INTO events VALUES ('x')`); err != nil {
+		t.Fatal(err)
+	}
+
+	tbl := typedTable(t)
+	if err := tbl.WriteSQL(ctx, db, "events", table.WithSQLMode(table.SQLReplace)); err != nil {
+		t.Fatalf("WriteSQL: %v", err)
+	}
+	assertSameTable(t, queryTable(t, db, "SELECT * FROM events"), tbl)
+
+	if err := tbl.WriteSQL(ctx, db, "fresh", table.WithSQLMode(table.SQLReplace)); err != nil {
+		t.Fatalf("WriteSQL of a new table: %v", err)
+	}
+	assertSameTable(t, queryTable(t, db, "SELECT * FROM fresh"), tbl)
+}
+
+func TestWriteSQLQuotedIdentifiers(t *testing.T) {
+	db := openSQLite(t)
+
+	tbl, err := New(map[string][]any{
+		`select`:      {int64(1), int64(2)},
+		`my "column"`: {"a", "b"},
+		"with space":  {1.5, nil},
+	}, []string{"select", `my "column"`, "with space"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tbl.WriteSQL(context.Background(), db, `order "items"`); err != nil {
+		t.Fatalf("WriteSQL: %v", err)
+	}
+	assertSameTable(t, queryTable(t, db, `SELECT * FROM "order ""items"""`), tbl)
+}
+
+func TestWriteSQLParameterLimit(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+
+	// a single connection, limited to the 999 parameters of SQLite before version 3.32
+	db.SetMaxOpenConns(1)
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sqlite.Limit(conn, sqlitelib.SQLITE_LIMIT_VARIABLE_NUMBER, 999); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	const columns, rows = 12, 250
+	data := map[string][]any{}
+	names := make([]string, columns)
+	for j := range names {
+		names[j] = fmt.Sprintf("c%d", j)
+		data[names[j]] = make([]any, rows)
+		for i := range data[names[j]] {
+			data[names[j]][i] = int64(i*columns + j)
+		}
+	}
+	tbl, err := New(data, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tbl.WriteSQL(ctx, db, "wide", table.WithSQLBatchSize(rows)); err != nil {
+		t.Fatalf("WriteSQL: %v", err)
+	}
+	assertSameTable(t, queryTable(t, db, "SELECT * FROM wide"), tbl)
+}
+
+func TestWriteSQLRollback(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+
+	if _, err := db.Exec(`CREATE TABLE events (id BIGINT NOT NULL); INSERT INTO events VALUES (0)`); err != nil {
+		t.Fatal(err)
+	}
+
+	tbl, err := New(map[string][]any{"id": {int64(1), int64(2), nil, int64(4)}}, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbl.WriteSQL(ctx, db, "events", table.WithSQLMode(table.SQLAppend), table.WithSQLBatchSize(2))
+	if err == nil || !strings.Contains(err.Error(), "failed inserting rows 2 to 3") {
+		t.Fatalf("err = %v, want an insert error", err)
+	}
+	if got := queryTable(t, db, "SELECT id FROM events").MustCol("id").Values(); len(got) != 1 || got[0] != int64(0) {
+		t.Errorf("events holds %v after the failed append, want [0]", got)
+	}
+}
+
+func TestWriteSQLErrors(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+	tbl := typedTable(t)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		table string
+		opts  []table.WriteSQLOption
+		want  string
+	}{
+		{"dialect", ctx, "t", []table.WriteSQLOption{table.WithSQLDialect(9)}, "unknown sql dialect 9"},
+		{"mode", ctx, "t", []table.WriteSQLOption{table.WithSQLMode(9)}, "unknown sql write mode 9"},
+		{"batch size", ctx, "t", []table.WriteSQLOption{table.WithSQLBatchSize(0)}, "sql batch size must be positive"},
+		{"table name", ctx, "", nil, "empty sql table name"},
+		{"missing table", ctx, "missing", []table.WriteSQLOption{table.WithSQLMode(table.SQLAppend)}, "failed inserting rows"},
+		{"canceled", canceled, "t", nil, "context canceled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tbl.WriteSQL(tt.ctx, db, tt.table, tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := db.Exec("SELECT * FROM t"); err == nil {
+		t.Errorf("table t was created by a failed WriteSQL")
+	}
+}